@@ -0,0 +1,7 @@
+action "nso_device_compare_config" "ce0" {
+  config {
+    device       = "ce0"
+    outformat    = "cli"
+    fail_on_diff = true
+  }
+}
@@ -0,0 +1,18 @@
+action "nso_device_connect" "ce0" {
+  config {
+    device = nso_device.ce0.name
+  }
+}
+
+resource "nso_device" "ce0" {
+  name      = "ce0"
+  address   = "127.0.0.1"
+  port      = 10022
+  authgroup = "default"
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.nso_device_connect.ce0]
+    }
+  }
+}
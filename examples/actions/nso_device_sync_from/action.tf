@@ -0,0 +1,18 @@
+action "nso_device_sync_from" "ce0" {
+  config {
+    device = "ce0"
+  }
+}
+
+resource "nso_device_config" "ce0" {
+  device = "ce0"
+  attributes = {
+    "tailf-ned-cisco-ios:hostname" = "ce0"
+  }
+  lifecycle {
+    action_trigger {
+      events  = [before_create]
+      actions = [action.nso_device_sync_from.ce0]
+    }
+  }
+}
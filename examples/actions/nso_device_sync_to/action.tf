@@ -0,0 +1,6 @@
+action "nso_device_sync_to" "ce0" {
+  config {
+    device  = "ce0"
+    dry_run = true
+  }
+}
@@ -0,0 +1,5 @@
+action "nso_packages_reload" "example" {
+  config {
+    force = true
+  }
+}
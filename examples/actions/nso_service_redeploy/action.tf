@@ -0,0 +1,6 @@
+action "nso_service_redeploy" "vpn1" {
+  config {
+    path      = "tailf-ncs:services/l3vpn:vpn/l3vpn=vpn1"
+    reconcile = true
+  }
+}
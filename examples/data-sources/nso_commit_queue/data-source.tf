@@ -0,0 +1,3 @@
+data "nso_commit_queue" "example" {
+  status = "failed"
+}
@@ -0,0 +1,2 @@
+
+data "nso_device_groups" "example" {}
@@ -0,0 +1,4 @@
+data "nso_ios_interface_gigabitethernet" "example" {
+  device = "ce0"
+  name   = "0/1"
+}
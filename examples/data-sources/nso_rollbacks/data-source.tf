@@ -0,0 +1,3 @@
+data "nso_rollbacks" "example" {
+  label = "change-1234"
+}
@@ -0,0 +1,8 @@
+ephemeral "nso_restconf" "example" {
+  path = "tailf-ncs:devices/authgroups/group=default/umap=admin"
+}
+
+ephemeral "nso_restconf" "host_keys" {
+  path  = "tailf-ncs:devices/device=ce0/ssh/fetch-host-keys"
+  input = {}
+}
@@ -0,0 +1 @@
+ephemeral "nso_session_token" "example" {}
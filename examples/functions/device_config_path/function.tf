@@ -0,0 +1,3 @@
+data "nso_restconf" "domain" {
+  path = provider::nso::device_config_path("ce0", "tailf-ned-cisco-ios:ip/domain")
+}
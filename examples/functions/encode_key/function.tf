@@ -0,0 +1,7 @@
+resource "nso_device_config" "interface" {
+  device = "ce0"
+  path   = "tailf-ned-cisco-ios:interface/GigabitEthernet=${provider::nso::encode_key(["0/1"])}"
+  attributes = {
+    "description" = "uplink"
+  }
+}
@@ -0,0 +1,9 @@
+output "interface_path" {
+  value = provider::nso::restconf_path([
+    ["tailf-ncs:devices"],
+    ["device", "ce0"],
+    ["config"],
+    ["tailf-ned-cisco-ios:interface"],
+    ["GigabitEthernet", "0/1"],
+  ])
+}
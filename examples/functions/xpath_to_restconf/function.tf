@@ -0,0 +1,3 @@
+data "nso_restconf" "interface" {
+  path = provider::nso::xpath_to_restconf("/ncs:devices/device[name='ce0']/config/tailf-ned-cisco-ios:interface/GigabitEthernet[name='0/1']")
+}
@@ -0,0 +1,3 @@
+list "nso_device" "all" {
+  provider = nso
+}
@@ -0,0 +1,8 @@
+list "nso_device_config" "interfaces" {
+  provider = nso
+  config {
+    device = "ce0"
+    path   = "tailf-ned-cisco-ios:interface/GigabitEthernet"
+    key    = "name"
+  }
+}
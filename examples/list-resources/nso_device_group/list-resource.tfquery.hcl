@@ -0,0 +1,3 @@
+list "nso_device_group" "all" {
+  provider = nso
+}
@@ -0,0 +1,6 @@
+import {
+  to = nso_device.example
+  identity = {
+    name = "test-device01"
+  }
+}
@@ -1,8 +1,11 @@
 resource "nso_device" "example" {
-  name        = "test-device01"
-  address     = "10.1.1.1"
-  port        = 22
-  authgroup   = "default"
-  admin_state = "locked"
-  cli_ned_id  = "cisco-ios-cli-3.8:cisco-ios-cli-3.8"
+  name            = "test-device01"
+  address         = "10.1.1.1"
+  port            = 22
+  connect_timeout = 30
+  read_timeout    = 30
+  write_timeout   = 30
+  authgroup       = "default"
+  admin_state     = "locked"
+  cli_ned_id      = "cisco-ios-cli-3.8:cisco-ios-cli-3.8"
 }
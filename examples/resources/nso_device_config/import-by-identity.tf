@@ -0,0 +1,7 @@
+import {
+  to = nso_device_config.example
+  identity = {
+    device = "c1"
+    path   = "tailf-ned-cisco-ios:access-list/access-list=1"
+  }
+}
@@ -1 +1 @@
-terraform import nso_device_config.example "tailf-ned-cisco-ios:access-list/access-list=1"
+terraform import nso_device_config.example "tailf-ncs:devices/device=c1/config/tailf-ned-cisco-ios:access-list/access-list=1"
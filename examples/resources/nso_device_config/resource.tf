@@ -6,8 +6,9 @@ resource "nso_device_config" "example" {
 }
 
 resource "nso_device_config" "access_list" {
-  device = "c1"
-  path   = "tailf-ned-cisco-ios:access-list/access-list=1"
+  device                = "c1"
+  path                  = "tailf-ned-cisco-ios:access-list/access-list=1"
+  out_of_sync_behaviour = "sync_from"
   attributes = {
     id = 1
   }
@@ -0,0 +1,6 @@
+import {
+  to = nso_device_group.example
+  identity = {
+    name = "test-group1"
+  }
+}
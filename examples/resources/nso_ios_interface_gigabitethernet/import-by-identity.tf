@@ -0,0 +1,7 @@
+import {
+  to = nso_ios_interface_gigabitethernet.example
+  identity = {
+    device = "ce0"
+    name   = "0/1"
+  }
+}
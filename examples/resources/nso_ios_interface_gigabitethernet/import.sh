@@ -0,0 +1 @@
+terraform import nso_ios_interface_gigabitethernet.example "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1"
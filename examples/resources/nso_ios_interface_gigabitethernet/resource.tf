@@ -0,0 +1,14 @@
+resource "nso_ios_interface_gigabitethernet" "example" {
+  device            = "ce0"
+  name              = "0/1"
+  description       = "My Interface Description"
+  shutdown          = false
+  ipv4_address      = "10.1.1.1"
+  ipv4_address_mask = "255.255.255.0"
+  ipv4_secondary_addresses = {
+    "10.2.2.1" = {
+      mask      = "255.255.255.0"
+      secondary = true
+    }
+  }
+}
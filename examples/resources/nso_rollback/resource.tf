@@ -0,0 +1,4 @@
+resource "nso_rollback" "example" {
+  label = "change-1234"
+  paths = ["/ncs:devices/device{ce0}/config"]
+}
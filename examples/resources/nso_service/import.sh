@@ -0,0 +1 @@
+terraform import nso_service.example "l3vpn:l3vpn=vpn1"
@@ -0,0 +1,27 @@
+resource "nso_service" "example" {
+  path = "l3vpn:l3vpn=vpn1"
+  attributes = {
+    name                = "vpn1"
+    route-distinguisher = "65000:1"
+  }
+  lists = [
+    {
+      name = "endpoint"
+      key  = "id"
+      items = [
+        {
+          id        = "1"
+          device    = "ce0"
+          interface = "0/1"
+        }
+      ]
+    }
+  ]
+  wait_for_plan = {
+    timeout = 300
+  }
+  redeploy_trigger = {
+    version = "1"
+  }
+  undeploy_on_destroy = false
+}
@@ -33,8 +33,9 @@ const (
 )
 
 type YamlConfig struct {
-	Name        string `yaml:"name"`
-	DocCategory string `yaml:"doc_category"`
+	Name           string `yaml:"name"`
+	DocCategory    string `yaml:"doc_category"`
+	ListDataSource bool   `yaml:"list_data_source"`
 }
 
 var docPaths = []string{"./docs/data-sources/", "./docs/resources/"}
@@ -80,6 +81,18 @@ func main() {
 			s := string(content)
 			s = strings.ReplaceAll(s, `subcategory: ""`, `subcategory: "`+configs[i].DocCategory+`"`)
 
+			ioutil.WriteFile(filename, []byte(s), 0644)
+		}
+		if configs[i].ListDataSource {
+			filename := docPaths[0] + SnakeCase(configs[i].Name) + "s.md"
+			content, err := ioutil.ReadFile(filename)
+			if err != nil {
+				log.Fatalf("Error opening documentation: %v", err)
+			}
+
+			s := string(content)
+			s = strings.ReplaceAll(s, `subcategory: ""`, `subcategory: "`+configs[i].DocCategory+`"`)
+
 			ioutil.WriteFile(filename, []byte(s), 0644)
 		}
 	}
@@ -98,6 +111,25 @@ func main() {
 		ioutil.WriteFile(filename, []byte(s), 0644)
 	}
 
+	// update nso_service resource
+	filename := docPaths[1] + "service.md"
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Error opening documentation: %v", err)
+	}
+	s := strings.ReplaceAll(string(content), `subcategory: ""`, `subcategory: "General"`)
+	ioutil.WriteFile(filename, []byte(s), 0644)
+
+	// update nso_rollback resource, nso_rollbacks and nso_commit_queue data sources
+	for _, filename := range []string{docPaths[1] + "rollback.md", docPaths[0] + "rollbacks.md", docPaths[0] + "commit_queue.md"} {
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			log.Fatalf("Error opening documentation: %v", err)
+		}
+		s := strings.ReplaceAll(string(content), `subcategory: ""`, `subcategory: "General"`)
+		ioutil.WriteFile(filename, []byte(s), 0644)
+	}
+
 	// update nso_device_config resource and data source
 	for _, path := range docPaths {
 		filename := path + "device_config.md"
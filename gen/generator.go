@@ -22,34 +22,49 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"math"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/openconfig/goyang/pkg/yang"
+	"golang.org/x/tools/imports"
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	definitionsPath   = "./gen/definitions/"
 	modelsPath        = "./gen/models/"
+	manifestPath      = "./gen/models.yaml"
 	providerTemplate  = "./gen/templates/provider.go"
 	providerLocation  = "./internal/provider/provider.go"
+	identityTemplate  = "./gen/templates/resource_identity_test.go"
+	identityLocation  = "./internal/provider/resource_identity_test.go"
 	changelogTemplate = "./gen/templates/changelog.md.tmpl"
 	changelogLocation = "./templates/guides/changelog.md.tmpl"
 	changelogOriginal = "./CHANGELOG.md"
+	schemaPath        = "./gen/schema/schema.yaml"
+	deviceConfigPath  = "tailf-ncs:devices/device=%v/config/"
+	deviceExample     = "ce0"
 )
 
 type t struct {
 	path   string
 	prefix string
 	suffix string
+	// only rendered for definitions with a list data source
+	listDataSource bool
 }
 
 var templates = []t{
@@ -58,6 +73,11 @@ var templates = []t{
 		prefix: "./internal/provider/model_nso_",
 		suffix: ".go",
 	},
+	{
+		path:   "./gen/templates/model_test.go",
+		prefix: "./internal/provider/model_nso_",
+		suffix: "_test.go",
+	},
 	{
 		path:   "./gen/templates/data_source.go",
 		prefix: "./internal/provider/data_source_nso_",
@@ -68,6 +88,18 @@ var templates = []t{
 		prefix: "./internal/provider/data_source_nso_",
 		suffix: "_test.go",
 	},
+	{
+		path:           "./gen/templates/data_source_list.go",
+		prefix:         "./internal/provider/data_source_nso_",
+		suffix:         "s.go",
+		listDataSource: true,
+	},
+	{
+		path:           "./gen/templates/data_source_list_test.go",
+		prefix:         "./internal/provider/data_source_nso_",
+		suffix:         "s_test.go",
+		listDataSource: true,
+	},
 	{
 		path:   "./gen/templates/resource.go",
 		prefix: "./internal/provider/resource_nso_",
@@ -78,11 +110,23 @@ var templates = []t{
 		prefix: "./internal/provider/resource_nso_",
 		suffix: "_test.go",
 	},
+	{
+		path:           "./gen/templates/list_resource.go",
+		prefix:         "./internal/provider/list_resource_nso_",
+		suffix:         ".go",
+		listDataSource: true,
+	},
 	{
 		path:   "./gen/templates/data-source.tf",
 		prefix: "./examples/data-sources/nso_",
 		suffix: "/data-source.tf",
 	},
+	{
+		path:           "./gen/templates/data-source-list.tf",
+		prefix:         "./examples/data-sources/nso_",
+		suffix:         "s/data-source.tf",
+		listDataSource: true,
+	},
 	{
 		path:   "./gen/templates/resource.tf",
 		prefix: "./examples/resources/nso_",
@@ -93,55 +137,78 @@ var templates = []t{
 		prefix: "./examples/resources/nso_",
 		suffix: "/import.sh",
 	},
+	{
+		path:   "./gen/templates/import-by-identity.tf",
+		prefix: "./examples/resources/nso_",
+		suffix: "/import-by-identity.tf",
+	},
 }
 
 type YamlConfig struct {
-	Name                    string                `yaml:"name"`
-	Path                    string                `yaml:"path"`
-	AugmentPath             string                `yaml:"augment_path"`
-	NoDelete                bool                  `yaml:"no_delete"`
-	NoDeleteAttributes      bool                  `yaml:"no_delete_attributes"`
-	DefaultDeleteAttributes bool                  `yaml:"default_delete_attributes"`
-	ExcludeTest             bool                  `yaml:"exclude_test"`
-	NoAugmentConfig         bool                  `yaml:"no_augment_config"`
-	DsDescription           string                `yaml:"ds_description"`
-	ResDescription          string                `yaml:"res_description"`
-	DocCategory             string                `yaml:"doc_category"`
-	Attributes              []YamlConfigAttribute `yaml:"attributes"`
-	TestPrerequisites       []YamlTest            `yaml:"test_prerequisites"`
+	Name                    string                `yaml:"name,omitempty"`
+	Path                    string                `yaml:"path,omitempty"`
+	AugmentPath             string                `yaml:"augment_path,omitempty"`
+	NoDelete                bool                  `yaml:"no_delete,omitempty"`
+	NoDeleteAttributes      bool                  `yaml:"no_delete_attributes,omitempty"`
+	DefaultDeleteAttributes bool                  `yaml:"default_delete_attributes,omitempty"`
+	ExcludeTest             bool                  `yaml:"exclude_test,omitempty"`
+	NoAugmentConfig         bool                  `yaml:"no_augment_config,omitempty"`
+	DeviceConfig            bool                  `yaml:"device_config,omitempty"`
+	ListDataSource          bool                  `yaml:"list_data_source,omitempty"`
+	DeviceExample           string                `yaml:"device_example,omitempty"`
+	DsDescription           string                `yaml:"ds_description,omitempty"`
+	ResDescription          string                `yaml:"res_description,omitempty"`
+	DocCategory             string                `yaml:"doc_category,omitempty"`
+	Attributes              []YamlConfigAttribute `yaml:"attributes,omitempty"`
+	TestPrerequisites       []YamlTest            `yaml:"test_prerequisites,omitempty"`
 }
 
 type YamlConfigAttribute struct {
-	YangName  string `yaml:"yang_name"`
-	YangScope string `yaml:"yang_scope"`
-	TfName    string `yaml:"tf_name"`
-	XPath     string `yaml:"xpath"`
-	Type      string `yaml:"type"`
+	YangName  string `yaml:"yang_name,omitempty"`
+	YangScope string `yaml:"yang_scope,omitempty"`
+	TfName    string `yaml:"tf_name,omitempty"`
+	XPath     string `yaml:"xpath,omitempty"`
+	Type      string `yaml:"type,omitempty"`
 	// "empty", "presence" or "boolean"
-	TypeYangBool    string                `yaml:"type_yang_bool"`
-	Id              bool                  `yaml:"id"`
-	Reference       bool                  `yaml:"reference"`
-	Mandatory       bool                  `yaml:"mandatory"`
-	Optional        bool                  `yaml:"optional"`
-	WriteOnly       bool                  `yaml:"write_only"`
-	ExcludeTest     bool                  `yaml:"exclude_test"`
-	ExcludeExample  bool                  `yaml:"exclude_example"`
-	Description     string                `yaml:"description"`
-	Example         string                `yaml:"example"`
-	EnumValues      []string              `yaml:"enum_values"`
-	MinInt          int64                 `yaml:"min_int"`
-	MaxInt          int64                 `yaml:"max_int"`
-	MinFloat        float64               `yaml:"min_float"`
-	MaxFloat        float64               `yaml:"max_float"`
-	StringPatterns  []string              `yaml:"string_patterns"`
-	StringMinLength int64                 `yaml:"string_min_length"`
-	StringMaxLength int64                 `yaml:"string_max_length"`
-	DefaultValue    string                `yaml:"default_value"`
-	RequiresReplace bool                  `yaml:"requires_replace"`
-	NoAugmentConfig bool                  `yaml:"no_augment_config"`
-	DeleteParent    bool                  `yaml:"delete_parent"`
-	NoDelete        bool                  `yaml:"no_delete"`
-	Attributes      []YamlConfigAttribute `yaml:"attributes"`
+	TypeYangBool    string                `yaml:"type_yang_bool,omitempty"`
+	Id              bool                  `yaml:"id,omitempty"`
+	Reference       bool                  `yaml:"reference,omitempty"`
+	Mandatory       bool                  `yaml:"mandatory,omitempty"`
+	Optional        bool                  `yaml:"optional,omitempty"`
+	WriteOnly       bool                  `yaml:"write_only,omitempty"`
+	ExcludeTest     bool                  `yaml:"exclude_test,omitempty"`
+	ExcludeExample  bool                  `yaml:"exclude_example,omitempty"`
+	Description     string                `yaml:"description,omitempty"`
+	Example         string                `yaml:"example,omitempty"`
+	EnumValues      []string              `yaml:"enum_values,omitempty"`
+	MinInt          int64                 `yaml:"min_int,omitempty"`
+	MaxInt          int64                 `yaml:"max_int,omitempty"`
+	MinFloat        float64               `yaml:"min_float,omitempty"`
+	MaxFloat        float64               `yaml:"max_float,omitempty"`
+	FractionDigits  int                   `yaml:"fraction_digits,omitempty"`
+	StringPatterns  []string              `yaml:"string_patterns,omitempty"`
+	StringMinLength int64                 `yaml:"string_min_length,omitempty"`
+	StringMaxLength int64                 `yaml:"string_max_length,omitempty"`
+	DefaultValue    string                `yaml:"default_value,omitempty"`
+	RequiresReplace bool                  `yaml:"requires_replace,omitempty"`
+	Sensitive       bool                  `yaml:"sensitive,omitempty"`
+	Leafref         string                `yaml:"leafref,omitempty"`
+	LeafrefResource string                `yaml:"-"`
+	NoAugmentConfig bool                  `yaml:"no_augment_config,omitempty"`
+	DeleteParent    bool                  `yaml:"delete_parent,omitempty"`
+	NoDelete        bool                  `yaml:"no_delete,omitempty"`
+	YangChoice      string                `yaml:"yang_choice,omitempty"`
+	YangCase        string                `yaml:"yang_case,omitempty"`
+	ChoiceMandatory bool                  `yaml:"choice_mandatory,omitempty"`
+	ConflictsWith   []string              `yaml:"conflicts_with,omitempty"`
+	ExactlyOneOf    []string              `yaml:"exactly_one_of,omitempty"`
+	AtLeastOneOf    []string              `yaml:"at_least_one_of,omitempty"`
+	AlsoRequires    []string              `yaml:"also_requires,omitempty"`
+	When            string                `yaml:"when,omitempty"`
+	WhenAttribute   string                `yaml:"when_attribute,omitempty"`
+	WhenValues      []string              `yaml:"when_values,omitempty"`
+	Must            []string              `yaml:"must,omitempty"`
+	Attributes      []YamlConfigAttribute `yaml:"attributes,omitempty"`
 }
 
 type YamlTest struct {
@@ -231,17 +298,104 @@ func HasId(attributes []YamlConfigAttribute) bool {
 	return false
 }
 
+// Templating helper function to return true if a write-only attribute is included in attributes
+func HasWriteOnly(attributes []YamlConfigAttribute) bool {
+	for _, attr := range attributes {
+		if attr.WriteOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// Templating helper function to return the id and reference attributes, in the order of the path keys
+func GetKeyAttributes(attributes []YamlConfigAttribute) []YamlConfigAttribute {
+	var keys []YamlConfigAttribute
+	for _, attr := range attributes {
+		if attr.Id || attr.Reference {
+			keys = append(keys, attr)
+		}
+	}
+	return keys
+}
+
+// Templating helper function to return true if reference included in attributes
+func HasReference(attributes []YamlConfigAttribute) bool {
+	for _, attr := range attributes {
+		if attr.Reference {
+			return true
+		}
+	}
+	return false
+}
+
+// Templating helper function to return true if a leafref is included in the top-level attributes
+func HasLeafref(attributes []YamlConfigAttribute) bool {
+	for _, attr := range attributes {
+		if attr.Leafref != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Templating helper function to return true if a leafref references the config of the same device
+func IsDeviceLeafref(p string) bool {
+	return strings.HasPrefix(p, deviceConfigPath)
+}
+
+// Templating helper function to get the list referenced by a leafref, without key and device config prefix
+func LeafrefTarget(p string) string {
+	return GetListPath(strings.TrimPrefix(p, deviceConfigPath))
+}
+
+// Templating helper function to get the path of the list containing an object (removes key of last path element)
+func GetListPath(p string) string {
+	return listKeyRegex.ReplaceAllString(p, "")
+}
+
+var listKeyRegex = regexp.MustCompile(`=[^/]*$`)
+
+// Templating helper function to build a RESTCONF "fields" expression selecting the modeled leaves of a list entry
+func GetFields(attributes []YamlConfigAttribute) string {
+	fields := make([]string, 0, len(attributes))
+	for _, attr := range attributes {
+		if attr.Reference || attr.WriteOnly {
+			continue
+		}
+		field := attr.YangName
+		if attr.XPath != "" {
+			field = attr.XPath
+		}
+		if len(attr.Attributes) > 0 {
+			field += "(" + GetFields(attr.Attributes) + ")"
+		}
+		fields = append(fields, field)
+	}
+	return strings.Join(fields, ";")
+}
+
 // Templating helper function to get example dn
 func GetExamplePath(path string, attributes []YamlConfigAttribute) string {
 	a := make([]interface{}, 0, len(attributes))
 	for _, attr := range attributes {
 		if attr.Id || attr.Reference {
-			a = append(a, attr.Example)
+			a = append(a, strings.ReplaceAll(url.QueryEscape(attr.Example), "+", "%20"))
 		}
 	}
 	return fmt.Sprintf(path, a...)
 }
 
+// Templating helper function to get example value of (first) key attribute
+func GetKeyExample(attributes []YamlConfigAttribute) string {
+	for _, attr := range attributes {
+		if attr.Id {
+			return attr.Example
+		}
+	}
+	return ""
+}
+
 // Templating helper function to identify last element of list
 func IsLast(index int, len int) bool {
 	return index+1 == len
@@ -260,6 +414,93 @@ func GetXPath(yangPath, xPath string) string {
 	return yangPath
 }
 
+// Templating helper function to return true if attribute has any validators
+func HasValidators(attr YamlConfigAttribute) bool {
+	if len(attr.ConflictsWith) > 0 || len(attr.ExactlyOneOf) > 0 || len(attr.AtLeastOneOf) > 0 || len(attr.AlsoRequires) > 0 || attr.WhenAttribute != "" {
+		return true
+	}
+	switch ElementType(attr.Type) {
+	case "String":
+		return len(attr.EnumValues) > 0 || len(attr.StringPatterns) > 0 || attr.StringMinLength != 0 || attr.StringMaxLength != 0
+	case "Int64":
+		return attr.MinInt != 0 || attr.MaxInt != 0
+	case "Float64":
+		return attr.MinFloat != 0 || attr.MaxFloat != 0
+	}
+	return false
+}
+
+// Templating helper function to get validator type of attribute type (e.g. Int64List -> List)
+func ValidatorType(t string) string {
+	if IsLeafList(t) {
+		return CollectionType(t)
+	}
+	return t
+}
+
+// Templating helper function to get validator package of attribute type (e.g. Int64 -> int64validator)
+func ValidatorPackage(t string) string {
+	return strings.ToLower(ValidatorType(t)) + "validator"
+}
+
+// Templating helper function to return true if type is a nested list, set or map
+func IsNested(t string) bool {
+	return t == "List" || t == "Set" || t == "Map"
+}
+
+// Templating helper function to get schema attribute type (e.g. Set -> SetNested, Int64List -> List)
+func SchemaType(t string) string {
+	if IsNested(t) {
+		return t + "Nested"
+	}
+	if IsLeafList(t) {
+		return CollectionType(t)
+	}
+	return t
+}
+
+// Templating helper function to return the plan modifier type of a type
+func PlanModifierType(t string) string {
+	if IsLeafList(t) {
+		return CollectionType(t)
+	}
+	return t
+}
+
+// Templating helper function to return true if type is a nested list or set
+func IsNestedList(t string) bool {
+	return t == "List" || t == "Set"
+}
+
+// Templating helper function to return true if type is a list or set of primitive values (YANG leaf-list)
+func IsLeafList(t string) bool {
+	return contains([]string{"StringList", "Int64List", "Float64List", "StringSet", "Int64Set", "Float64Set"}, t)
+}
+
+// Templating helper function to get collection type of a leaf-list type (e.g. StringSet -> Set)
+func CollectionType(t string) string {
+	if strings.HasSuffix(t, "Set") {
+		return "Set"
+	}
+	return "List"
+}
+
+// Templating helper function to get element type of a leaf-list type (e.g. StringSet -> String)
+func ElementType(t string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(t, "List"), "Set")
+}
+
+// Templating helper function to get GO element type of a leaf-list type (e.g. Int64List -> int)
+func ElementGoType(t string) string {
+	switch ElementType(t) {
+	case "Int64":
+		return "int"
+	case "Float64":
+		return "float64"
+	}
+	return "string"
+}
+
 func contains(s []string, str string) bool {
 	for _, v := range s {
 		if v == str {
@@ -276,14 +517,43 @@ var functions = template.FuncMap{
 	"camelCase":             CamelCase,
 	"snakeCase":             SnakeCase,
 	"hasId":                 HasId,
+	"hasWriteOnly":          HasWriteOnly,
+	"getKeyAttributes":      GetKeyAttributes,
 	"getExamplePath":        GetExamplePath,
+	"getKeyExample":         GetKeyExample,
 	"isLast":                IsLast,
 	"sprintf":               fmt.Sprintf,
 	"removeLastPathElement": RemoveLastPathElement,
 	"getXPath":              GetXPath,
+	"hasValidators":         HasValidators,
+	"validatorType":         ValidatorType,
+	"validatorPackage":      ValidatorPackage,
+	"schemaType":            SchemaType,
+	"isNested":              IsNested,
+	"isNestedList":          IsNestedList,
+	"isLeafList":            IsLeafList,
+	"collectionType":        CollectionType,
+	"hasReference":          HasReference,
+	"getListPath":           GetListPath,
+	"getFields":             GetFields,
+	"hasLeafref":            HasLeafref,
+	"isDeviceLeafref":       IsDeviceLeafref,
+	"leafrefTarget":         LeafrefTarget,
+	"planModifierType":      PlanModifierType,
+	"elementType":           ElementType,
+	"elementGoType":         ElementGoType,
 }
 
 func resolvePath(e *yang.Entry, path string) *yang.Entry {
+	leaf := lookupPath(e, path)
+	if leaf == nil {
+		panic(fmt.Sprintf("Failed to resolve YANG path: %s", path))
+	}
+	return leaf
+}
+
+// Resolve a YANG path relative to entry e, returns nil if the path does not exist
+func lookupPath(e *yang.Entry, path string) *yang.Entry {
 	pathElements := strings.Split(path, "/")
 
 	for _, pathElement := range pathElements {
@@ -297,7 +567,7 @@ func resolvePath(e *yang.Entry, path string) *yang.Entry {
 				pathElement = pathElement[strings.Index(pathElement, ":")+1:]
 			}
 			if _, ok := e.Dir[pathElement]; !ok {
-				panic(fmt.Sprintf("Failed to resolve YANG path: %s, element: %s", path, pathElement))
+				return nil
 			}
 			e = e.Dir[pathElement]
 		}
@@ -345,64 +615,237 @@ func addKeys(e *yang.Entry, config *YamlConfig) {
 	}
 }
 
+// Encrypted strings and digests of tailf-common
+var sensitiveTypeRegex = regexp.MustCompile(`(encrypted|digest)-string$`)
+
+var (
+	intKinds     = []string{"int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64"}
+	stringKinds  = []string{"string", "leafref", "identityref", "instance-identifier", "binary", "bits", "enumeration"}
+	decimalKinds = []string{"decimal64"}
+	boolKinds    = []string{"boolean", "empty"}
+)
+
+// Convert a YANG number to int64, values exceeding int64 are capped
+func numberToInt64(n yang.Number) int64 {
+	// hack to not introduce unsigned types
+	if n.Value > math.MaxInt64 {
+		if n.Negative {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	if n.Negative {
+		return -int64(n.Value)
+	}
+	return int64(n.Value)
+}
+
+// Convert a YANG number (integer or decimal) to float64
+func numberToFloat64(n yang.Number) float64 {
+	f, _ := strconv.ParseFloat(n.String(), 64)
+	return f
+}
+
+// Flatten union member types, nested unions are resolved recursively
+func unionTypes(t *yang.YangType) []*yang.YangType {
+	var types []*yang.YangType
+	for _, m := range t.Type {
+		if m.Kind.String() == "union" {
+			types = append(types, unionTypes(m)...)
+		} else {
+			types = append(types, m)
+		}
+	}
+	return types
+}
+
+// Derive the Terraform type of a union, which is Int64 or Float64 if all members are numeric and String otherwise
+func unionKind(t *yang.YangType) string {
+	kind := ""
+	for _, m := range unionTypes(t) {
+		k := m.Kind.String()
+		if contains(intKinds, k) && (kind == "" || kind == "Int64") {
+			kind = "Int64"
+		} else if (contains(intKinds, k) || contains(decimalKinds, k)) && (kind == "" || kind == "Int64" || kind == "Float64") {
+			kind = "Float64"
+		} else {
+			return "String"
+		}
+	}
+	return kind
+}
+
+// Build a pattern matching a space separated set of bit names
+func bitsPattern(t *yang.YangType) string {
+	names := make([]string, 0)
+	for _, name := range t.Bit.Names() {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	bit := "(" + strings.Join(names, "|") + ")"
+	return "^(" + bit + "( " + bit + ")*)?$"
+}
+
+// Set integer range of attribute, multiple ranges are merged to a single range
+func parseIntRange(r yang.YangRange, attr *YamlConfigAttribute) {
+	if len(r) == 0 {
+		return
+	}
+	attr.MinInt = numberToInt64(r[0].Min)
+	attr.MaxInt = numberToInt64(r[len(r)-1].Max)
+}
+
+// Set float range of attribute, multiple ranges are merged to a single range
+func parseFloatRange(r yang.YangRange, attr *YamlConfigAttribute) {
+	if len(r) == 0 {
+		return
+	}
+	// skip implicit decimal64 range
+	if r[0].Min.Value >= math.MaxInt64 && r[len(r)-1].Max.Value >= math.MaxInt64 {
+		return
+	}
+	attr.MinFloat = numberToFloat64(r[0].Min)
+	attr.MaxFloat = numberToFloat64(r[len(r)-1].Max)
+}
+
+// Parse YANG type and derive Terraform type and validators, leaf-lists are represented as
+// sets unless "ordered-by user" is set
+func parseType(t *yang.YangType, attr *YamlConfigAttribute, listAttr *yang.ListAttr) {
+	kind := t.Kind.String()
+	leafList := listAttr != nil
+	suffix := ""
+	if leafList {
+		suffix = "Set"
+		if listAttr.OrderedByUser {
+			suffix = "List"
+		}
+	}
+	if kind == "union" {
+		members := unionTypes(t)
+		switch unionKind(t) {
+		case "Int64":
+			attr.Type = "Int64" + suffix
+			for _, m := range members {
+				if len(m.Range) > 0 {
+					min, max := numberToInt64(m.Range[0].Min), numberToInt64(m.Range[len(m.Range)-1].Max)
+					if attr.MinInt == 0 && attr.MaxInt == 0 || min < attr.MinInt {
+						attr.MinInt = min
+					}
+					if max > attr.MaxInt {
+						attr.MaxInt = max
+					}
+				}
+			}
+		case "Float64":
+			attr.Type = "Float64" + suffix
+			for _, m := range members {
+				if m.FractionDigits > attr.FractionDigits {
+					attr.FractionDigits = m.FractionDigits
+				}
+			}
+		default:
+			attr.Type = "String" + suffix
+			// restrict values only if all members are enumerations
+			enums := make([]string, 0)
+			for _, m := range members {
+				if m.Kind.String() != "enumeration" {
+					enums = nil
+					break
+				}
+				enums = append(enums, m.Enum.Names()...)
+			}
+			if len(enums) > 0 {
+				attr.EnumValues = enums
+			}
+		}
+		return
+	}
+	if contains(intKinds, kind) {
+		attr.Type = "Int64" + suffix
+		parseIntRange(t.Range, attr)
+	} else if contains(decimalKinds, kind) {
+		attr.Type = "Float64" + suffix
+		attr.FractionDigits = t.FractionDigits
+		parseFloatRange(t.Range, attr)
+	} else if contains(boolKinds, kind) {
+		if leafList {
+			// leaf-lists of type empty are not allowed, booleans are represented as strings
+			attr.Type = "String" + suffix
+			attr.EnumValues = []string{"true", "false"}
+			return
+		}
+		attr.TypeYangBool = kind
+		attr.Type = "Bool"
+	} else if contains(stringKinds, kind) {
+		attr.Type = "String" + suffix
+		switch kind {
+		case "string":
+			if len(t.Length) > 0 {
+				attr.StringMinLength = int64(t.Length[0].Min.Value)
+				attr.StringMaxLength = int64(t.Length[len(t.Length)-1].Max.Value)
+			}
+			if len(t.Pattern) > 0 {
+				attr.StringPatterns = t.Pattern
+			}
+		case "enumeration":
+			attr.EnumValues = t.Enum.Names()
+		case "bits":
+			attr.StringPatterns = []string{bitsPattern(t)}
+		case "identityref":
+			attr.StringPatterns = []string{`^([A-Za-z_][A-Za-z0-9_.-]*:)?[A-Za-z_][A-Za-z0-9_.-]*$`}
+		case "instance-identifier":
+			attr.StringPatterns = []string{`^/`}
+		case "binary":
+			attr.StringPatterns = []string{`^[A-Za-z0-9+/]*={0,2}$`}
+			if len(t.Length) > 0 {
+				attr.StringMinLength = int64(t.Length[0].Min.Value)
+				attr.StringMaxLength = int64(t.Length[len(t.Length)-1].Max.Value)
+			}
+		}
+	} else {
+		panic(fmt.Sprintf("Unknown leaf type, attribute: %s, type: %s", attr.YangName, kind))
+	}
+}
+
 func parseAttribute(e *yang.Entry, attr *YamlConfigAttribute) {
 	leaf := resolvePath(e, attr.YangName)
 	//fmt.Printf("%s, Entry: %+v\n\n", attr.YangName, e)
 	//fmt.Printf("%s, Kind: %+v, ListAttr: %+v, Type: %+v\n\n", leaf.Name, leaf.Kind, leaf.ListAttr, leaf.Type)
 	if leaf.Kind.String() == "Leaf" {
-		if leaf.ListAttr != nil {
-			if contains([]string{"string", "union", "leafref"}, leaf.Type.Kind.String()) {
-				attr.Type = "StringList"
-			} else if contains([]string{"uint8", "uint16", "uint32", "uint64"}, leaf.Type.Kind.String()) {
-				attr.Type = "Int64List"
-			} else {
-				panic(fmt.Sprintf("Unknown leaf-list type, attribute: %s, type: %s", attr.YangName, leaf.Type.Kind.String()))
-			}
-			// TODO parse union type
-		} else if contains([]string{"string", "union", "leafref"}, leaf.Type.Kind.String()) {
-			attr.Type = "String"
-			if leaf.Type.Length != nil {
-				attr.StringMinLength = int64(leaf.Type.Length[0].Min.Value)
-				attr.StringMaxLength = int64(leaf.Type.Length[0].Max.Value)
-			}
-			if len(leaf.Type.Pattern) > 0 {
-				attr.StringPatterns = leaf.Type.Pattern
-			}
-		} else if contains([]string{"uint8", "uint16", "uint32", "uint64"}, leaf.Type.Kind.String()) {
-			attr.Type = "Int64"
-			if leaf.Type.Range != nil {
-				attr.MinInt = int64(leaf.Type.Range[0].Min.Value)
-				max := leaf.Type.Range[0].Max.Value
-				// hack to not introduce unsigned types
-				if max > math.MaxInt64 {
-					max = math.MaxInt64
+		t := leaf.Type
+		if t.Kind.String() == "leafref" {
+			// use the type of the referenced leaf, leafrefs to list keys are checked for existence
+			if target, restconfPath := resolveLeafref(leaf); target != nil {
+				t = target.Type
+				if attr.Leafref == "" {
+					attr.Leafref = restconfPath
 				}
-				attr.MaxInt = int64(max)
-			}
-		} else if contains([]string{"decimal8", "decimal16", "decimal32", "decimal64"}, leaf.Type.Kind.String()) {
-			attr.Type = "Float64"
-			if leaf.Type.Range != nil {
-				attr.MinFloat = float64(leaf.Type.Range[0].Min.Value)
-				attr.MaxFloat = float64(leaf.Type.Range[0].Max.Value)
-			}
-		} else if contains([]string{"boolean", "empty"}, leaf.Type.Kind.String()) {
-			if leaf.Type.Kind.String() == "boolean" {
-				attr.TypeYangBool = "boolean"
-			} else if leaf.Type.Kind.String() == "empty" {
-				attr.TypeYangBool = "empty"
-			}
-			attr.Type = "Bool"
-		} else if contains([]string{"enumeration"}, leaf.Type.Kind.String()) {
-			attr.Type = "String"
-			attr.EnumValues = leaf.Type.Enum.Names()
-		} else {
-			panic(fmt.Sprintf("Unknown leaf type, attribute: %s, type: %s", attr.YangName, leaf.Type.Kind.String()))
+			}
 		}
+		parseType(t, attr, leaf.ListAttr)
+	} else if leaf.IsList() && attr.Type == "List" && !leaf.ListAttr.OrderedByUser {
+		// lists ordered by system are represented as sets
+		attr.Type = "Set"
 	}
 	if _, ok := leaf.Extra["presence"]; ok {
 		attr.TypeYangBool = "presence"
 		attr.Type = "Bool"
 	}
+	if attr.YangChoice == "" {
+		parseChoice(e, leaf, attr)
+	}
+	if attr.When == "" {
+		attr.When, _ = leaf.GetWhenXPath()
+	}
+	if len(attr.Must) == 0 {
+		attr.Must = mustXPaths(leaf.Node)
+	}
+	// defaults of choices and conditional leaves must not be sent unless configured
+	if attr.DefaultValue == "" && attr.YangChoice == "" && attr.When == "" && leaf.Kind.String() == "Leaf" && leaf.ListAttr == nil && attr.TypeYangBool != "empty" {
+		attr.DefaultValue, _ = leaf.SingleDefaultValue()
+	}
+	if leaf.Type != nil && sensitiveTypeRegex.MatchString(leaf.Type.Name) {
+		attr.Sensitive = true
+	}
 	if attr.TfName == "" {
 		tfName := strings.ReplaceAll(ToYangShortName(attr.YangName), "-", "_")
 		tfName = strings.ReplaceAll(tfName, "/", "_")
@@ -427,144 +870,1333 @@ func parseAttribute(e *yang.Entry, attr *YamlConfigAttribute) {
 	}
 }
 
-func augmentConfig(config *YamlConfig, modelPaths []string) {
-	path := ""
-	if config.AugmentPath != "" {
-		path = config.AugmentPath
-	} else {
-		path = config.Path
-	}
+var leafrefPredicateRegex = regexp.MustCompile(`\[[^\]]*\]`)
 
-	module := strings.Split(path, ":")[0]
-	e, errors := yang.GetModule(module, modelPaths...)
-	if len(errors) > 0 {
-		fmt.Printf("YANG parser error(s): %+v\n\n", errors)
-		return
+// Find a child of a schema node, choices and cases are transparent as they are not part of data paths
+func schemaChild(e *yang.Entry, name string) *yang.Entry {
+	if child, ok := e.Dir[name]; ok {
+		return child
 	}
-
-	p := path[len(module)+1:]
-	e = resolvePath(e, p)
-
-	addKeys(e, config)
-
-	for ia := range config.Attributes {
-		if config.Attributes[ia].Id || config.Attributes[ia].Reference || config.Attributes[ia].NoAugmentConfig {
-			continue
-		}
-		parseAttribute(e, &config.Attributes[ia])
-		if config.Attributes[ia].Type == "List" {
-			el := resolvePath(e, config.Attributes[ia].YangName)
-			for iaa := range config.Attributes[ia].Attributes {
-				if config.Attributes[ia].Attributes[iaa].NoAugmentConfig {
-					continue
-				}
-				parseAttribute(el, &config.Attributes[ia].Attributes[iaa])
-				if config.Attributes[ia].Attributes[iaa].Type == "List" {
-					ell := resolvePath(el, config.Attributes[ia].Attributes[iaa].YangName)
-					for iaaa := range config.Attributes[ia].Attributes[iaa].Attributes {
-						if config.Attributes[ia].Attributes[iaa].Attributes[iaaa].NoAugmentConfig {
-							continue
-						}
-						parseAttribute(ell, &config.Attributes[ia].Attributes[iaa].Attributes[iaaa])
-					}
-				}
+	for _, child := range e.Dir {
+		if child.IsChoice() || child.IsCase() {
+			if c := schemaChild(child, name); c != nil {
+				return c
 			}
 		}
 	}
+	return nil
+}
 
-	if config.DsDescription == "" {
-		config.DsDescription = fmt.Sprintf("This data source can read the %s configuration.", config.Name)
-	}
-	if config.ResDescription == "" {
-		config.ResDescription = fmt.Sprintf("This resource can manage the %s configuration.", config.Name)
+// Parent of a schema node skipping choices and cases
+func schemaParent(e *yang.Entry) *yang.Entry {
+	p := e.Parent
+	for p != nil && (p.IsChoice() || p.IsCase()) {
+		p = p.Parent
 	}
+	return p
 }
 
-func renderTemplate(templatePath, outputPath string, config interface{}) {
-	file, err := os.Open(templatePath)
-	if err != nil {
-		log.Fatalf("Error opening template: %v", err)
+// Name of the module defining a schema node, submodules resolve to the module they belong to
+func moduleName(e *yang.Entry) string {
+	m := yang.RootNode(e.Node)
+	if m == nil {
+		return ""
 	}
-	defer file.Close()
+	if m.BelongsTo != nil {
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
 
-	// skip first line with 'build-ignore' directive for go files
-	scanner := bufio.NewScanner(file)
-	if strings.HasSuffix(templatePath, ".go") {
-		scanner.Scan()
+// Resolve the target of a leafref, returns the referenced leaf and the RESTCONF path of the list entry it is the
+// key of ("%v" as key placeholder). The path is empty if the target is not the single key of a list or if the
+// target list is nested in another list, as the keys of outer lists are not known.
+func resolveLeafref(leaf *yang.Entry) (*yang.Entry, string) {
+	p := leafrefPredicateRegex.ReplaceAllString(strings.TrimSpace(leaf.Type.Path), "")
+	e := leaf
+	if strings.HasPrefix(p, "/") {
+		for e.Parent != nil {
+			e = e.Parent
+		}
 	}
-	var temp string
-	for scanner.Scan() {
-		temp = temp + scanner.Text() + "\n"
+	for _, element := range strings.Split(p, "/") {
+		switch element {
+		case "":
+		case "..":
+			e = schemaParent(e)
+		default:
+			e = schemaChild(e, ToYangShortName(element))
+		}
+		if e == nil {
+			return nil, ""
+		}
 	}
-
-	template, err := template.New(path.Base(templatePath)).Funcs(functions).Parse(temp)
-	if err != nil {
-		log.Fatalf("Error parsing template: %v", err)
+	list := schemaParent(e)
+	if list == nil || !list.IsList() || list.Key != e.Name {
+		return e, ""
 	}
-
-	// create output file
-	outputFile := filepath.Join(outputPath)
-	os.MkdirAll(filepath.Dir(outputFile), 0755)
-	f, err := os.Create(outputFile)
-	if err != nil {
-		log.Fatalf("Error creating output file: %v", err)
+	nodes := []*yang.Entry{}
+	for n := list; schemaParent(n) != nil; n = schemaParent(n) {
+		if n != list && n.IsList() {
+			return e, ""
+		}
+		nodes = append([]*yang.Entry{n}, nodes...)
 	}
-
-	output := new(bytes.Buffer)
-	err = template.Execute(output, config)
-	if err != nil {
-		log.Fatalf("Error executing template: %v", err)
+	// the module prefix is required for the first element and whenever the namespace changes
+	elements := make([]string, len(nodes))
+	for i, n := range nodes {
+		elements[i] = n.Name
+		if i == 0 || moduleName(n) != moduleName(nodes[i-1]) {
+			elements[i] = moduleName(n) + ":" + n.Name
+		}
 	}
-	f.Write(output.Bytes())
+	return e, strings.Join(elements, "/") + "=%v"
 }
 
-func main() {
-	items, _ := ioutil.ReadDir(definitionsPath)
-	configs := make([]YamlConfig, len(items))
-
-	// Load configs
-	for i, filename := range items {
-		yamlFile, err := ioutil.ReadFile(filepath.Join(definitionsPath, filename.Name()))
-		if err != nil {
-			log.Fatalf("Error reading file: %v", err)
-		}
-
-		config := YamlConfig{}
-		err = yaml.Unmarshal(yamlFile, &config)
-		if err != nil {
-			log.Fatalf("Error parsing yaml: %v", err)
+// Record the innermost choice and case of an attribute below entry e
+func parseChoice(e, leaf *yang.Entry, attr *YamlConfigAttribute) {
+	child := leaf
+	for parent := leaf.Parent; parent != nil && parent != e; parent = parent.Parent {
+		if parent.IsChoice() {
+			attr.YangChoice = strings.TrimPrefix(parent.Path(), e.Path()+"/")
+			attr.YangCase = child.Name
+			attr.ChoiceMandatory = parent.Mandatory.Value()
+			return
 		}
-		configs[i] = config
+		child = parent
 	}
+}
 
-	items, _ = ioutil.ReadDir(modelsPath)
-	modelPaths := make([]string, 0)
-
-	// Iterate over yang models
-	for _, item := range items {
-		if filepath.Ext(item.Name()) == ".yang" {
-			modelPaths = append(modelPaths, filepath.Join(modelsPath, item.Name()))
+// Derive the choices and cases of a definition which is not augmented from the YANG model, if the model is cached.
+// Without the model yang_choice and yang_case have to be maintained by hand, they are verified once it is pinned.
+func addChoices(config *YamlConfig) {
+	module := configModule(*config)
+	path := config.Path
+	if config.AugmentPath != "" {
+		path = config.AugmentPath
+	}
+	e, errors := getModule(module)
+	if len(errors) > 0 {
+		return
+	}
+	e = lookupPath(e, path[len(module)+1:])
+	if e == nil {
+		panic(fmt.Sprintf("Failed to resolve YANG path: %s", path))
+	}
+	var add func(e *yang.Entry, attributes []YamlConfigAttribute)
+	add = func(e *yang.Entry, attributes []YamlConfigAttribute) {
+		for i := range attributes {
+			attr := &attributes[i]
+			if attr.Id || attr.Reference {
+				continue
+			}
+			leaf := lookupPath(e, attr.YangName)
+			if leaf == nil {
+				panic(fmt.Sprintf("Failed to resolve YANG path: %s", attr.YangName))
+			}
+			derived := YamlConfigAttribute{}
+			parseChoice(e, leaf, &derived)
+			choice := derived.YangChoice[strings.LastIndex(derived.YangChoice, "/")+1:]
+			if attr.YangChoice != "" && (attr.YangChoice != choice && attr.YangChoice != derived.YangChoice || attr.YangCase != derived.YangCase) {
+				panic(fmt.Sprintf("attribute %s: yang_choice %s and yang_case %s do not match choice %q and case %q of the YANG model", attr.TfName, attr.YangChoice, attr.YangCase, derived.YangChoice, derived.YangCase))
+			}
+			attr.YangChoice, attr.YangCase = derived.YangChoice, derived.YangCase
+			if derived.YangChoice != "" {
+				attr.ChoiceMandatory = derived.ChoiceMandatory
+			}
+			if IsNested(attr.Type) {
+				add(leaf, attr.Attributes)
+			}
 		}
 	}
+	add(e, config.Attributes)
+}
 
-	for i := range configs {
-		// Augment config by yang models
-		if !configs[i].NoAugmentConfig {
-			augmentConfig(&configs[i], modelPaths)
+// Get must expressions of a YANG node
+func mustXPaths(n yang.Node) []string {
+	var must []*yang.Must
+	switch n := n.(type) {
+	case *yang.Leaf:
+		must = n.Must
+	case *yang.LeafList:
+		must = n.Must
+	case *yang.Container:
+		must = n.Must
+	case *yang.List:
+		must = n.Must
+	}
+	xpaths := make([]string, 0)
+	for _, m := range must {
+		xpaths = append(xpaths, strings.Join(strings.Fields(m.Name), " "))
+	}
+	return xpaths
+}
+
+var (
+	whenExistsRegex = regexp.MustCompile(`^((?:\.\./)+[\w.:/-]+)$`)
+	whenEqualsRegex = regexp.MustCompile(`^((?:\.\./)+[\w.:/-]+)\s*=\s*['"]([^'"]*)['"]$`)
+)
+
+// Strip module prefixes from all path elements
+func stripPrefixes(p string) string {
+	elements := strings.Split(p, "/")
+	for i := range elements {
+		elements[i] = ToYangShortName(elements[i])
+	}
+	return strings.Join(elements, "/")
+}
+
+// Find the sibling attribute referenced by a relative XPath expression
+func findAttribute(attributes []YamlConfigAttribute, attr *YamlConfigAttribute, rel string) *YamlConfigAttribute {
+	target := path.Join(stripPrefixes(GetXPath(attr.YangName, attr.XPath)), stripPrefixes(rel))
+	for i := range attributes {
+		if &attributes[i] != attr && stripPrefixes(GetXPath(attributes[i].YangName, attributes[i].XPath)) == target {
+			return &attributes[i]
+		}
+	}
+	return nil
+}
+
+// Translate simple when expressions referencing a sibling attribute to validators,
+// "../a" requires attribute a to be configured and "../a = 'x' or ../a = 'y'" requires one of the values
+func parseWhen(attributes []YamlConfigAttribute, attr *YamlConfigAttribute) {
+	when := strings.Join(strings.Fields(attr.When), " ")
+	if when == "" || attr.WhenAttribute != "" {
+		return
+	}
+	if m := whenExistsRegex.FindStringSubmatch(when); m != nil {
+		if target := findAttribute(attributes, attr, m[1]); target != nil && !contains(attr.AlsoRequires, target.TfName) {
+			attr.AlsoRequires = append(attr.AlsoRequires, target.TfName)
+		}
+		return
+	}
+	var target *YamlConfigAttribute
+	values := make([]string, 0)
+	for _, expr := range strings.Split(when, " or ") {
+		m := whenEqualsRegex.FindStringSubmatch(strings.TrimSpace(expr))
+		if m == nil {
+			return
+		}
+		t := findAttribute(attributes, attr, m[1])
+		if t == nil || (target != nil && t != target) {
+			return
+		}
+		target = t
+		values = append(values, m[2])
+	}
+	attr.WhenAttribute = target.TfName
+	attr.WhenValues = values
+}
+
+// Derive validators from choice/case membership and when expressions of attributes
+func addConstraints(attributes []YamlConfigAttribute) {
+	for i := range attributes {
+		attr := &attributes[i]
+		if attr.Id || attr.Reference {
+			continue
+		}
+		if attr.YangChoice != "" {
+			siblings := make([]string, 0)
+			cases := make(map[string]int)
+			for _, a := range attributes {
+				if a.Id || a.Reference || a.YangChoice != attr.YangChoice {
+					continue
+				}
+				cases[a.YangCase]++
+				if a.TfName == attr.TfName {
+					continue
+				}
+				siblings = append(siblings, a.TfName)
+				if a.YangCase != attr.YangCase && !contains(attr.ConflictsWith, a.TfName) {
+					attr.ConflictsWith = append(attr.ConflictsWith, a.TfName)
+				}
+			}
+			if attr.ChoiceMandatory && len(attr.ExactlyOneOf) == 0 && len(attr.AtLeastOneOf) == 0 {
+				// exactly one of is only correct if every case consists of a single attribute
+				single := true
+				for _, count := range cases {
+					if count > 1 {
+						single = false
+					}
+				}
+				if single {
+					// exactly one of implies conflicts with all other cases
+					attr.ExactlyOneOf = siblings
+					attr.ConflictsWith = nil
+				} else {
+					attr.AtLeastOneOf = siblings
+				}
+			}
+		}
+		parseWhen(attributes, attr)
+		if IsNested(attr.Type) {
+			addConstraints(attr.Attributes)
+		}
+	}
+}
+
+// Map-keyed lists are only supported as top-level attributes with a single key attribute and no nested lists
+func checkMaps(attributes []YamlConfigAttribute, nested bool) {
+	for _, attr := range attributes {
+		if attr.Type == "Map" {
+			if nested {
+				panic(fmt.Sprintf("Map attribute %s is only supported at the top level", attr.TfName))
+			}
+			keys := 0
+			for _, child := range attr.Attributes {
+				if child.Id {
+					keys++
+				}
+				if IsNested(child.Type) {
+					panic(fmt.Sprintf("Map attribute %s must not contain nested lists, attribute: %s", attr.TfName, child.TfName))
+				}
+			}
+			if keys != 1 {
+				panic(fmt.Sprintf("Map attribute %s requires exactly one key (id) attribute", attr.TfName))
+			}
+		}
+		checkMaps(attr.Attributes, true)
+	}
+}
+
+// Load a YANG module from the model cache, imports and includes are resolved from the cache as well
+func getModule(name string) (*yang.Entry, []error) {
+	ms := yang.NewModules()
+	ms.AddPath(modelsPath)
+	return ms.GetModule(name)
+}
+
+// Augment a definition by its YANG model, returns an error if the model cannot be loaded
+func augmentConfig(config *YamlConfig) error {
+	path := ""
+	if config.AugmentPath != "" {
+		path = config.AugmentPath
+	} else {
+		path = config.Path
+	}
+
+	module := strings.Split(path, ":")[0]
+	e, errors := getModule(module)
+	if len(errors) > 0 {
+		return fmt.Errorf("YANG parser error(s): %+v", errors)
+	}
+
+	p := path[len(module)+1:]
+	e = resolvePath(e, p)
+
+	addKeys(e, config)
+
+	for ia := range config.Attributes {
+		if config.Attributes[ia].Id || config.Attributes[ia].Reference || config.Attributes[ia].NoAugmentConfig {
+			continue
+		}
+		parseAttribute(e, &config.Attributes[ia])
+		if IsNested(config.Attributes[ia].Type) {
+			el := resolvePath(e, config.Attributes[ia].YangName)
+			for iaa := range config.Attributes[ia].Attributes {
+				if config.Attributes[ia].Attributes[iaa].NoAugmentConfig {
+					continue
+				}
+				parseAttribute(el, &config.Attributes[ia].Attributes[iaa])
+				if IsNested(config.Attributes[ia].Attributes[iaa].Type) {
+					ell := resolvePath(el, config.Attributes[ia].Attributes[iaa].YangName)
+					for iaaa := range config.Attributes[ia].Attributes[iaa].Attributes {
+						if config.Attributes[ia].Attributes[iaa].Attributes[iaaa].NoAugmentConfig {
+							continue
+						}
+						parseAttribute(ell, &config.Attributes[ia].Attributes[iaa].Attributes[iaaa])
+					}
+				}
+			}
+		}
+	}
+
+	if config.DsDescription == "" {
+		config.DsDescription = fmt.Sprintf("This data source can read the %s configuration.", config.Name)
+	}
+	if config.ResDescription == "" {
+		config.ResDescription = fmt.Sprintf("This resource can manage the %s configuration.", config.Name)
+	}
+	return nil
+}
+
+// Child entries of a YANG node which hold configuration, sorted by name
+func configChildren(e *yang.Entry) []*yang.Entry {
+	names := make([]string, 0, len(e.Dir))
+	for name := range e.Dir {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	children := make([]*yang.Entry, 0, len(names))
+	for _, name := range names {
+		child := e.Dir[name]
+		if child.RPC != nil || child.ReadOnly() {
+			continue
+		}
+		switch child.Kind {
+		case yang.LeafEntry, yang.DirectoryEntry, yang.ChoiceEntry, yang.CaseEntry:
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Get an example value matching the type and validators of an attribute
+func scaffoldExample(attr *YamlConfigAttribute) string {
+	if len(attr.EnumValues) > 0 {
+		return attr.EnumValues[0]
+	}
+	switch ElementType(attr.Type) {
+	case "Int64":
+		if attr.MinInt > 1 {
+			return strconv.FormatInt(attr.MinInt, 10)
+		} else if attr.MaxInt < 1 && attr.MinInt < attr.MaxInt {
+			return strconv.FormatInt(attr.MaxInt, 10)
+		}
+		return "1"
+	case "Float64":
+		if attr.MinFloat > 1 {
+			return strconv.FormatFloat(attr.MinFloat, 'f', -1, 64)
+		}
+		return "1.5"
+	case "Bool":
+		return "true"
+	}
+	return "example"
+}
+
+// Build attributes for all configurable leaves below entry e, containers, choices and cases are
+// flattened, lists become nested attributes with their keys marked as id
+func scaffoldAttributes(root, e *yang.Entry, yangPrefix, xPrefix string, depth int) []YamlConfigAttribute {
+	attributes := make([]YamlConfigAttribute, 0)
+	for _, child := range configChildren(e) {
+		yangName := path.Join(yangPrefix, child.Name)
+		xPath := xPrefix
+		if !child.IsChoice() && !child.IsCase() {
+			xPath = path.Join(xPrefix, child.Name)
+		}
+		_, presence := child.Extra["presence"]
+		if (child.IsContainer() && !presence) || child.IsChoice() || child.IsCase() {
+			attributes = append(attributes, scaffoldAttributes(root, child, yangName, xPath, depth)...)
+			continue
+		}
+		attr := YamlConfigAttribute{YangName: yangName, TfName: strings.ReplaceAll(strings.ReplaceAll(xPath, "-", "_"), "/", "_")}
+		if xPath != yangName {
+			attr.XPath = xPath
+		}
+		if child.IsList() {
+			if depth >= 2 {
+				fmt.Fprintf(os.Stderr, "Skipping list %s, only two levels of nested lists are supported\n", child.Path())
+				continue
+			}
+			attr.Type = "List"
+			attr.Attributes = scaffoldAttributes(child, child, "", "", depth+1)
+			for _, key := range strings.Split(child.Key, " ") {
+				for i := range attr.Attributes {
+					if attr.Attributes[i].YangName == key {
+						attr.Attributes[i].Id = true
+					}
+				}
+			}
+		}
+		parseAttribute(root, &attr)
+		if !IsNested(attr.Type) {
+			attr.Example = scaffoldExample(&attr)
+		}
+		attributes = append(attributes, attr)
+	}
+	return attributes
+}
+
+// Exclude attributes of all but the first case of a choice from tests, as only a single case can be configured
+func excludeAlternativeCases(attributes []YamlConfigAttribute) {
+	cases := make(map[string]string)
+	for i := range attributes {
+		if attributes[i].YangChoice != "" {
+			if c, ok := cases[attributes[i].YangChoice]; !ok {
+				cases[attributes[i].YangChoice] = attributes[i].YangCase
+			} else if c != attributes[i].YangCase {
+				attributes[i].ExcludeTest = true
+			}
+		}
+		if IsNested(attributes[i].Type) {
+			excludeAlternativeCases(attributes[i].Attributes)
+		}
+	}
+}
+
+// Scaffold a definition from a YANG path, list keys along the path are added as reference
+// and id attributes in the order they appear in the path
+func scaffoldConfig(yangPath, name string) YamlConfig {
+	module := strings.Split(yangPath, ":")[0]
+	e, errors := getModule(module)
+	if len(errors) > 0 {
+		log.Fatalf("YANG parser error(s): %+v", errors)
+	}
+
+	config := YamlConfig{Name: name, DeviceConfig: strings.HasPrefix(module, "tailf-ned-")}
+	keys := make([]YamlConfigAttribute, 0)
+	elements := strings.Split(yangPath[len(module)+1:], "/")
+	for i, element := range elements {
+		e = lookupPath(e, element)
+		if e == nil {
+			log.Fatalf("Failed to resolve YANG path: %s, element: %s", yangPath, element)
+		}
+		if !e.IsList() {
+			continue
+		}
+		if !strings.Contains(element, "=") {
+			elements[i] = element + "=" + strings.TrimSuffix(strings.Repeat("%v,", len(strings.Split(e.Key, " "))), ",")
+		}
+		for _, key := range strings.Split(e.Key, " ") {
+			keys = append(keys, YamlConfigAttribute{YangName: key, YangScope: e.Name, TfName: strings.ReplaceAll(e.Name+"_"+key, "-", "_"), Reference: true})
+		}
+	}
+	config.Path = module + ":" + strings.Join(elements, "/")
+	if config.Name == "" {
+		config.Name = strings.Title(strings.ReplaceAll(e.Name, "-", " "))
+	}
+	config.DocCategory = strings.Title(strings.ReplaceAll(strings.Split(elements[0], "=")[0], "-", " "))
+
+	attributes := scaffoldAttributes(e, e, "", "", 0)
+	excludeAlternativeCases(attributes)
+	if e.IsList() {
+		// keys of the target list are the id attributes, all keys of outer lists are references
+		targetKeys := strings.Split(e.Key, " ")
+		keys = keys[:len(keys)-len(targetKeys)]
+		for _, key := range targetKeys {
+			for i := range attributes {
+				if attributes[i].YangName == key {
+					attributes[i].Id = true
+					keys = append(keys, attributes[i])
+					attributes = append(attributes[:i], attributes[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	for i := range keys {
+		if keys[i].Reference {
+			parseAttribute(lookupScope(e, keys[i].YangScope), &keys[i])
+			keys[i].Example = scaffoldExample(&keys[i])
+		}
+	}
+	config.Attributes = append(keys, attributes...)
+	for i := range config.Attributes {
+		// "id" is reserved for the resource identifier
+		if config.Attributes[i].TfName == "id" {
+			config.Attributes[i].TfName = strings.ReplaceAll(e.Name, "-", "_") + "_id"
+		}
+	}
+	config.DsDescription = fmt.Sprintf("This data source can read the %s configuration.", config.Name)
+	config.ResDescription = fmt.Sprintf("This resource can manage the %s configuration.", config.Name)
+	return config
+}
+
+// Find the closest ancestor list of entry e with the given name
+func lookupScope(e *yang.Entry, name string) *yang.Entry {
+	for e != nil && !(e.IsList() && e.Name == name) {
+		e = e.Parent
+	}
+	return e
+}
+
+// Collect the YANG entries covered by a list of attributes
+func coveredEntries(e *yang.Entry, attributes []YamlConfigAttribute, covered map[*yang.Entry]bool) {
+	for _, attr := range attributes {
+		if attr.Id || attr.Reference {
+			if leaf := lookupPath(e, attr.YangName); leaf != nil {
+				covered[leaf] = true
+			}
+			continue
+		}
+		leaf := lookupPath(e, attr.YangName)
+		if leaf == nil {
+			continue
+		}
+		covered[leaf] = true
+		if IsNested(attr.Type) {
+			coveredEntries(leaf, attr.Attributes, covered)
+		}
+	}
+}
+
+// Collect the paths of all configurable leaves below entry e which are not covered
+func uncoveredLeaves(e *yang.Entry, covered map[*yang.Entry]bool) []string {
+	paths := make([]string, 0)
+	for _, child := range configChildren(e) {
+		_, presence := child.Extra["presence"]
+		if child.IsLeaf() || child.IsLeafList() || presence {
+			if !covered[child] {
+				paths = append(paths, child.Path())
+			}
+			continue
+		}
+		if child.IsList() && !covered[child] {
+			paths = append(paths, child.Path())
+			continue
+		}
+		paths = append(paths, uncoveredLeaves(child, covered)...)
+	}
+	return paths
+}
+
+// Module names listed in the model manifest, either as root module or as pinned model
+func manifestModules() (map[string]bool, error) {
+	manifestFile, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := struct {
+		Roots  []struct{ Name string } `yaml:"roots"`
+		Models []struct{ Name string } `yaml:"models"`
+	}{}
+	if err := yaml.Unmarshal(manifestFile, &manifest); err != nil {
+		return nil, err
+	}
+	modules := make(map[string]bool)
+	for _, m := range append(manifest.Roots, manifest.Models...) {
+		modules[m.Name] = true
+	}
+	return modules, nil
+}
+
+// Module of the YANG path of a definition
+func configModule(config YamlConfig) string {
+	if config.AugmentPath != "" {
+		return strings.Split(config.AugmentPath, ":")[0]
+	}
+	return strings.Split(config.Path, ":")[0]
+}
+
+// Report YANG leaves below the path of a definition which are not covered by its attributes
+func diffConfig(config YamlConfig) ([]string, error) {
+	yangPath := config.Path
+	if config.AugmentPath != "" {
+		yangPath = config.AugmentPath
+	}
+	module := strings.Split(yangPath, ":")[0]
+	e, errors := getModule(module)
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("YANG parser error(s): %+v", errors)
+	}
+	e = lookupPath(e, yangPath[len(module)+1:])
+	if e == nil {
+		return nil, fmt.Errorf("failed to resolve YANG path: %s", yangPath)
+	}
+	covered := make(map[*yang.Entry]bool)
+	coveredEntries(e, config.Attributes, covered)
+	return uncoveredLeaves(e, covered), nil
+}
+
+// Scope a definition of NED configuration under a device, the path of the definition is relative to the device configuration
+func addDeviceConfig(config *YamlConfig) {
+	if !strings.HasPrefix(config.Path, "tailf-ned-") {
+		fmt.Printf("Warning: path of device config definition %s does not start with a NED module prefix: %s\n", config.Name, config.Path)
+	}
+	if config.AugmentPath == "" {
+		config.AugmentPath = config.Path
+	}
+	config.Path = deviceConfigPath + config.Path
+	if config.DeviceExample == "" {
+		config.DeviceExample = deviceExample
+	}
+	device := YamlConfigAttribute{
+		YangName:        "name",
+		YangScope:       "device",
+		TfName:          "device",
+		Type:            "String",
+		Reference:       true,
+		NoAugmentConfig: true,
+		Description:     "An NSO device name.",
+		Example:         config.DeviceExample,
+	}
+	config.Attributes = append([]YamlConfigAttribute{device}, config.Attributes...)
+	prefixLeafrefs(config.Attributes)
+}
+
+// Leafrefs within the NED model are relative to the device config
+func prefixLeafrefs(attributes []YamlConfigAttribute) {
+	for i := range attributes {
+		if strings.HasPrefix(attributes[i].Leafref, "tailf-ned-") {
+			attributes[i].Leafref = deviceConfigPath + attributes[i].Leafref
+		}
+		prefixLeafrefs(attributes[i].Attributes)
+	}
+}
+
+// Execute a template, templates of go files start with a 'build-ignore' directive which is skipped
+func executeTemplate(templatePath string, config interface{}) ([]byte, error) {
+	file, err := os.Open(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening template: %v", err)
+	}
+	defer file.Close()
+
+	// skip first line with 'build-ignore' directive for go files
+	scanner := bufio.NewScanner(file)
+	if strings.HasSuffix(templatePath, ".go") {
+		scanner.Scan()
+	}
+	var temp string
+	for scanner.Scan() {
+		temp = temp + scanner.Text() + "\n"
+	}
+
+	template, err := template.New(path.Base(templatePath)).Funcs(functions).Parse(temp)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %v", err)
+	}
+
+	output := new(bytes.Buffer)
+	err = template.Execute(output, config)
+	if err != nil {
+		return nil, fmt.Errorf("error executing template: %v", err)
+	}
+	return output.Bytes(), nil
+}
+
+func renderTemplate(templatePath, outputPath string, config interface{}) error {
+	output, err := executeTemplate(templatePath, config)
+	if err != nil {
+		return err
+	}
+
+	// create output file
+	outputFile := filepath.Join(outputPath)
+	os.MkdirAll(filepath.Dir(outputFile), 0755)
+	return ioutil.WriteFile(outputFile, output, 0644)
+}
+
+// Format rendered output the same way "go generate" formats the written files
+func formatOutput(outputPath string, output []byte) ([]byte, error) {
+	switch filepath.Ext(outputPath) {
+	case ".go":
+		return imports.Process(outputPath, output, nil)
+	case ".tf":
+		return hclwrite.Format(output), nil
+	}
+	return output, nil
+}
+
+// Render a template in memory and compare it with the existing output file, a unified diff is
+// printed if the file is stale
+func checkTemplate(templatePath, outputPath string, config interface{}) (bool, error) {
+	output, err := executeTemplate(templatePath, config)
+	if err != nil {
+		return false, err
+	}
+	output, err = formatOutput(outputPath, output)
+	if err != nil {
+		return false, fmt.Errorf("error formatting %s: %v", outputPath, err)
+	}
+	current, err := ioutil.ReadFile(outputPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if bytes.Equal(current, output) {
+		return true, nil
+	}
+	fmt.Print(unifiedDiff(strings.TrimPrefix(outputPath, "./"), string(current), string(output)))
+	return false, nil
+}
+
+// Check that the documentation of a resource and data source has the category of its definition
+func checkDocCategory(name, category string, dataSourceOnly bool) []string {
+	var errors []string
+	docPaths := []string{"./docs/data-sources/", "./docs/resources/"}
+	if dataSourceOnly {
+		docPaths = docPaths[:1]
+	}
+	for _, docPath := range docPaths {
+		filename := docPath + name + ".md"
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			errors = append(errors, err.Error())
+			continue
+		}
+		if !strings.Contains(string(content), `subcategory: "`+category+`"`) {
+			errors = append(errors, fmt.Sprintf("%s: subcategory is not %q", filename, category))
+		}
+	}
+	return errors
+}
+
+// Find generated files which do not belong to any definition anymore
+func orphanedFiles(outputs map[string]bool) []string {
+	var orphaned []string
+	files, _ := filepath.Glob("./internal/provider/*.go")
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		if err != nil || !bytes.Contains(content, []byte(`Code generated by "gen/generator.go"; DO NOT EDIT.`)) {
+			continue
+		}
+		if !outputs[filepath.Clean(f)] {
+			orphaned = append(orphaned, f)
+		}
+	}
+	return orphaned
+}
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// Build a unified diff of two texts with three lines of context
+func unifiedDiff(name, a, b string) string {
+	const context = 3
+	al, bl := splitLines(a), splitLines(b)
+
+	// longest common subsequence of lines
+	lcs := make([][]int, len(al)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bl)+1)
+	}
+	for i := len(al) - 1; i >= 0; i-- {
+		for j := len(bl) - 1; j >= 0; j-- {
+			if al[i] == bl[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	ops := make([]diffOp, 0, len(al)+len(bl))
+	i, j := 0, 0
+	for i < len(al) || j < len(bl) {
+		switch {
+		case i < len(al) && j < len(bl) && al[i] == bl[j]:
+			ops = append(ops, diffOp{' ', al[i]})
+			i++
+			j++
+		case j == len(bl) || (i < len(al) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', al[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', bl[j]})
+			j++
+		}
+	}
+
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "--- a/%s\n+++ b/%s\n", name, name)
+	aLine, bLine := 0, 0
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			aLine++
+			bLine++
+			k++
+			continue
+		}
+		// extend hunk until the gap between changes exceeds twice the context
+		start := k - context
+		if start < 0 {
+			start = 0
+		}
+		end := k
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			gap := end
+			for gap < len(ops) && ops[gap].kind == ' ' {
+				gap++
+			}
+			if gap == len(ops) || gap-end > 2*context {
+				break
+			}
+			end = gap
+		}
+		stop := end + context
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+		aStart, bStart := aLine-(k-start), bLine-(k-start)
+		aLen, bLen := 0, 0
+		for _, op := range ops[start:stop] {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		for _, op := range ops[start:stop] {
+			fmt.Fprintf(out, "%c%s\n", op.kind, op.line)
+		}
+		aLine, bLine = aStart+aLen, bStart+bLen
+		k = stop
+	}
+	return out.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	// a missing newline at the end of the file changes the last line, it is marked like diff does
+	if !strings.HasSuffix(s, "\n") {
+		lines[len(lines)-1] += "\n\\ No newline at end of file"
+	}
+	return lines
+}
+
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// Validator of a definition value as described in gen/schema/schema.yaml, e.g. "list(include('attribute'), required=False)"
+type schemaValidator struct {
+	Name     string
+	Args     []schemaValidator
+	Values   []string
+	Required bool
+}
+
+type schema struct {
+	Root     map[string]schemaValidator
+	Includes map[string]map[string]schemaValidator
+}
+
+// Parse a validator expression, returns the remaining input
+func parseValidator(s string) (schemaValidator, string, error) {
+	v := schemaValidator{Required: true}
+	s = strings.TrimSpace(s)
+	open := strings.Index(s, "(")
+	if open < 0 {
+		return v, s, fmt.Errorf("invalid validator: %s", s)
+	}
+	v.Name = strings.TrimSpace(s[:open])
+	s = strings.TrimSpace(s[open+1:])
+	for !strings.HasPrefix(s, ")") {
+		switch {
+		case strings.HasPrefix(s, "'"):
+			end := strings.Index(s[1:], "'")
+			if end < 0 {
+				return v, s, fmt.Errorf("unterminated string: %s", s)
+			}
+			v.Values = append(v.Values, s[1:end+1])
+			s = s[end+2:]
+		case strings.HasPrefix(s, "required="):
+			s = strings.TrimPrefix(s, "required=")
+			v.Required = !strings.HasPrefix(s, "False")
+			s = strings.TrimLeft(s, "TrueFals")
+		default:
+			arg, rest, err := parseValidator(s)
+			if err != nil {
+				return v, s, err
+			}
+			v.Args = append(v.Args, arg)
+			s = rest
+		}
+		s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), ","))
+		if s == "" {
+			return v, s, fmt.Errorf("missing closing parenthesis in validator %s", v.Name)
+		}
+	}
+	return v, s[1:], nil
+}
+
+func parseSchemaMap(m map[string]string) (map[string]schemaValidator, error) {
+	validators := make(map[string]schemaValidator)
+	for key, expr := range m {
+		v, rest, err := parseValidator(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", key, err)
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, fmt.Errorf("%s: unexpected input: %s", key, rest)
+		}
+		validators[key] = v
+	}
+	return validators, nil
+}
+
+// Load a yamale schema, the first document describes a definition and the second document the includes
+func loadSchema(schemaPath string) (schema, error) {
+	s := schema{Includes: make(map[string]map[string]schemaValidator)}
+	data, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		return s, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	root := make(map[string]string)
+	if err := decoder.Decode(&root); err != nil {
+		return s, err
+	}
+	if s.Root, err = parseSchemaMap(root); err != nil {
+		return s, err
+	}
+	includes := make(map[string]map[string]string)
+	if err := decoder.Decode(&includes); err != nil {
+		return s, err
+	}
+	for name, include := range includes {
+		if s.Includes[name], err = parseSchemaMap(include); err != nil {
+			return s, fmt.Errorf("%s.%v", name, err)
+		}
+	}
+	return s, nil
+}
+
+// Validate a mapping node against a map of validators, errors are prefixed with file and line
+func (s schema) validateMap(file, p string, node *yaml.Node, validators map[string]schemaValidator) []string {
+	if node.Kind != yaml.MappingNode {
+		return []string{fmt.Sprintf("%s:%d:%d: %s: expected a map", file, node.Line, node.Column, p)}
+	}
+	var errors []string
+	found := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		kp := strings.TrimPrefix(p+"."+key.Value, ".")
+		v, ok := validators[key.Value]
+		if !ok {
+			errors = append(errors, fmt.Sprintf("%s:%d:%d: %s: unexpected key", file, key.Line, key.Column, kp))
+			continue
+		}
+		found[key.Value] = true
+		if value.Tag == "!!null" {
+			if v.Required {
+				errors = append(errors, fmt.Sprintf("%s:%d:%d: %s: required value missing", file, value.Line, value.Column, kp))
+			}
+			continue
+		}
+		errors = append(errors, s.validate(file, kp, value, v)...)
+	}
+	keys := make([]string, 0, len(validators))
+	for key := range validators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if validators[key].Required && !found[key] {
+			errors = append(errors, fmt.Sprintf("%s:%d:%d: %s: required key missing", file, node.Line, node.Column, strings.TrimPrefix(p+"."+key, ".")))
+		}
+	}
+	return errors
+}
+
+// Validate a node against a single validator
+func (s schema) validate(file, p string, node *yaml.Node, v schemaValidator) []string {
+	fail := func(format string, a ...interface{}) []string {
+		return []string{fmt.Sprintf("%s:%d:%d: %s: ", file, node.Line, node.Column, p) + fmt.Sprintf(format, a...)}
+	}
+	scalar := node.Kind == yaml.ScalarNode
+	switch v.Name {
+	case "str":
+		if !scalar || node.Tag != "!!str" {
+			return fail("expected a string")
+		}
+	case "int":
+		if !scalar || node.Tag != "!!int" {
+			return fail("expected an integer")
+		}
+	case "num":
+		if !scalar || (node.Tag != "!!int" && node.Tag != "!!float") {
+			return fail("expected a number")
+		}
+	case "bool":
+		if !scalar || node.Tag != "!!bool" {
+			return fail("expected a boolean")
+		}
+	case "enum":
+		if !scalar || !contains(v.Values, node.Value) {
+			return fail("%q is not one of %s", node.Value, strings.Join(v.Values, ", "))
+		}
+	case "any":
+		for _, arg := range v.Args {
+			if len(s.validate(file, p, node, arg)) == 0 {
+				return nil
+			}
+		}
+		names := make([]string, len(v.Args))
+		for i, arg := range v.Args {
+			names[i] = arg.Name
+		}
+		return fail("expected one of %s", strings.Join(names, ", "))
+	case "list":
+		if node.Kind != yaml.SequenceNode {
+			return fail("expected a list")
+		}
+		var errors []string
+		for i, item := range node.Content {
+			ip := fmt.Sprintf("%s.%d", p, i)
+			if len(v.Args) == 1 {
+				errors = append(errors, s.validate(file, ip, item, v.Args[0])...)
+			} else if len(v.Args) > 1 {
+				errors = append(errors, s.validate(file, ip, item, schemaValidator{Name: "any", Args: v.Args})...)
+			}
+		}
+		return errors
+	case "include":
+		include, ok := s.Includes[v.Values[0]]
+		if !ok {
+			return fail("unknown include %s", v.Values[0])
+		}
+		return s.validateMap(file, p, node, include)
+	default:
+		return fail("unknown validator %s", v.Name)
+	}
+	return nil
+}
+
+// Load a definition and validate it against the schema, the module is only checked against the model manifest if the
+// definition is valid
+func loadDefinition(file string, schema schema, modules map[string]bool) (YamlConfig, []string) {
+	config := YamlConfig{}
+	yamlFile, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatalf("Error reading file: %v", err)
+	}
+
+	node := yaml.Node{}
+	err = yaml.Unmarshal(yamlFile, &node)
+	if err != nil {
+		return config, []string{fmt.Sprintf("%s: %v", file, err)}
+	}
+	if len(node.Content) == 0 {
+		return config, []string{fmt.Sprintf("%s: empty definition", file)}
+	}
+	if errs := schema.validateMap(file, "", node.Content[0], schema.Root); len(errs) > 0 {
+		return config, errs
+	}
+	err = node.Decode(&config)
+	if err != nil {
+		return config, []string{fmt.Sprintf("%s: %v", file, err)}
+	}
+	if module := configModule(config); !modules[module] {
+		return config, []string{fmt.Sprintf("%s: module %s not listed in %s", filepath.Base(file), module, manifestPath)}
+	}
+	return config, nil
+}
+
+// Process a single definition, panics while augmenting are reported as errors. The error of loading the YANG model
+// is returned separately, as the definition can still be generated without it.
+func processConfig(config *YamlConfig) (yangErr error, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	// Augment config by yang models
+	if !config.NoAugmentConfig {
+		yangErr = augmentConfig(config)
+	} else {
+		addChoices(config)
+	}
+	if config.DeviceConfig {
+		addDeviceConfig(config)
+	}
+	addConstraints(config.Attributes)
+	checkMaps(config.Attributes, false)
+	if config.ListDataSource && GetListPath(config.Path) == config.Path {
+		return yangErr, fmt.Errorf("list_data_source requires a path ending with a list key")
+	}
+	return yangErr, checkLeafrefs(config, config.Attributes)
+}
+
+// A leafref path has a single key placeholder, device config paths have an additional placeholder for the device
+func checkLeafrefs(config *YamlConfig, attributes []YamlConfigAttribute) error {
+	for _, attr := range attributes {
+		if attr.Leafref != "" {
+			placeholders := strings.Count(attr.Leafref, "%v")
+			if IsDeviceLeafref(attr.Leafref) {
+				placeholders--
+				if !config.DeviceConfig {
+					return fmt.Errorf("leafref of attribute %s references device config, but definition is not a device config definition", attr.TfName)
+				}
+			}
+			if placeholders != 1 || !strings.HasSuffix(attr.Leafref, "=%v") {
+				return fmt.Errorf("leafref of attribute %s must be a list path ending with a single key placeholder: %s", attr.TfName, attr.Leafref)
+			}
+		}
+		if err := checkLeafrefs(config, attr.Attributes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Link leafrefs to the resource managing the referenced list, used for documentation
+func linkLeafrefs(configs []YamlConfig) {
+	resources := make(map[string]string)
+	for _, config := range configs {
+		resources[config.Path] = SnakeCase(config.Name)
+	}
+	var link func(attributes []YamlConfigAttribute)
+	link = func(attributes []YamlConfigAttribute) {
+		for i := range attributes {
+			if attributes[i].Leafref != "" {
+				attributes[i].LeafrefResource = resources[attributes[i].Leafref]
+			}
+			link(attributes[i].Attributes)
+		}
+	}
+	for i := range configs {
+		link(configs[i].Attributes)
+	}
+}
+
+func main() {
+	scaffold := flag.String("scaffold", "", "scaffold a definition from a YANG path, e.g. tailf-ncs:devices/authgroups/group")
+	name := flag.String("name", "", "name of the scaffolded definition, derived from the YANG path if empty")
+	diff := flag.Bool("diff", false, "report YANG leaves not covered by definitions")
+	check := flag.Bool("check", false, "report generated files which are not up-to-date without writing any files")
+	flag.Parse()
+
+	if *scaffold != "" {
+		config := scaffoldConfig(*scaffold, *name)
+		output := new(bytes.Buffer)
+		encoder := yaml.NewEncoder(output)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(config); err != nil {
+			log.Fatalf("Error encoding yaml: %v", err)
+		}
+		fmt.Print("---\n" + output.String())
+		return
+	}
+
+	schema, err := loadSchema(schemaPath)
+	if err != nil {
+		log.Fatalf("Error loading schema: %v", err)
+	}
+
+	// Every module has to be pinned to make the generated code reproducible
+	modules, err := manifestModules()
+	if err != nil {
+		log.Fatalf("Error loading model manifest: %v", err)
+	}
+
+	items, _ := ioutil.ReadDir(definitionsPath)
+	configs := make([]YamlConfig, len(items))
+	var errors []string
+
+	// Load and validate configs
+	for i, filename := range items {
+		var errs []string
+		configs[i], errs = loadDefinition(filepath.Join(definitionsPath, filename.Name()), schema, modules)
+		errors = append(errors, errs...)
+	}
+	if len(errors) > 0 {
+		for _, e := range errors {
+			fmt.Println(e)
+		}
+		os.Exit(1)
+	}
+
+	if *diff {
+		failed := false
+		for i := range configs {
+			paths, err := diffConfig(configs[i])
+			if err != nil {
+				fmt.Printf("%s: skipped, %v\n", items[i].Name(), err)
+				failed = true
+				continue
+			}
+			for _, p := range paths {
+				fmt.Printf("%s: %s not covered\n", items[i].Name(), p)
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	stale := 0
+	outputs := make(map[string]bool)
+	render := func(templatePath, outputPath string, config interface{}) error {
+		outputs[filepath.Clean(outputPath)] = true
+		if !*check {
+			return renderTemplate(templatePath, outputPath, config)
+		}
+		upToDate, err := checkTemplate(templatePath, outputPath, config)
+		if !upToDate {
+			stale++
+		}
+		return err
+	}
+
+	processed := make([]bool, len(configs))
+	for i := range configs {
+		yangErr, err := processConfig(&configs[i])
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", items[i].Name(), err))
+			continue
+		}
+		if yangErr != nil {
+			// generated files would differ from files generated with the YANG model
+			if *check {
+				errors = append(errors, fmt.Sprintf("%s: YANG model not loaded, run \"go run gen/load_models.go\": %v", items[i].Name(), yangErr))
+			} else {
+				fmt.Printf("%s: %v\n\n", items[i].Name(), yangErr)
+			}
+		}
+		processed[i] = true
+	}
+	linkLeafrefs(configs)
+
+	for i := range configs {
+		if !processed[i] {
+			continue
 		}
 
 		// Iterate over templates and render files
 		for _, t := range templates {
-			renderTemplate(t.path, t.prefix+SnakeCase(configs[i].Name)+t.suffix, configs[i])
+			if t.listDataSource && !configs[i].ListDataSource {
+				continue
+			}
+			if err := render(t.path, t.prefix+SnakeCase(configs[i].Name)+t.suffix, configs[i]); err != nil {
+				errors = append(errors, fmt.Sprintf("%s: %s: %v", items[i].Name(), t.path, err))
+			}
+		}
+		if *check {
+			errors = append(errors, checkDocCategory(SnakeCase(configs[i].Name), configs[i].DocCategory, false)...)
+			if configs[i].ListDataSource {
+				errors = append(errors, checkDocCategory(SnakeCase(configs[i].Name)+"s", configs[i].DocCategory, true)...)
+			}
 		}
 	}
 
 	// render provider.go
-	renderTemplate(providerTemplate, providerLocation, configs)
+	if err := render(providerTemplate, providerLocation, configs); err != nil {
+		errors = append(errors, fmt.Sprintf("%s: %v", providerTemplate, err))
+	}
+
+	// render the identity tests of all resources
+	if err := render(identityTemplate, identityLocation, configs); err != nil {
+		errors = append(errors, fmt.Sprintf("%s: %v", identityTemplate, err))
+	}
 
 	changelog, err := ioutil.ReadFile(changelogOriginal)
 	if err != nil {
-		log.Fatalf("Error reading changelog: %v", err)
+		errors = append(errors, fmt.Sprintf("Error reading changelog: %v", err))
+	} else if err := render(changelogTemplate, changelogLocation, string(changelog)); err != nil {
+		errors = append(errors, fmt.Sprintf("%s: %v", changelogTemplate, err))
+	}
+
+	if *check {
+		for _, f := range orphanedFiles(outputs) {
+			errors = append(errors, fmt.Sprintf("%s: generated file without definition", f))
+		}
+	}
+	for _, e := range errors {
+		fmt.Println(e)
+	}
+	if len(errors) > 0 || stale > 0 {
+		if stale > 0 {
+			fmt.Printf("%d generated file(s) not up-to-date, run \"go generate\"\n", stale)
+		}
+		os.Exit(1)
 	}
-	renderTemplate(changelogTemplate, changelogLocation, string(changelog))
 }
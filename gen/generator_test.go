@@ -0,0 +1,225 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build ignore
+
+// The generator tests are run with "go test gen/generator.go gen/generator_test.go".
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// The templates and definitions are referenced relative to the repository root
+	if err := os.Chdir(".."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestModelTemplateDeletedNestedListItems(t *testing.T) {
+	config := YamlConfig{
+		Name: "Test",
+		Path: "test:tests/test=%v",
+		Attributes: []YamlConfigAttribute{
+			{YangName: "name", TfName: "name", Type: "String", Id: true, Example: "a"},
+			{YangName: "outer", TfName: "outers", Type: "List", Attributes: []YamlConfigAttribute{
+				{YangName: "id", TfName: "id", Type: "String", Id: true, Example: "b"},
+				{YangName: "inner", TfName: "inners", Type: "List", Attributes: []YamlConfigAttribute{
+					{YangName: "id", TfName: "id", Type: "String", Id: true, Example: "c"},
+				}},
+			}},
+		},
+	}
+	output, err := executeTemplate("./gen/templates/model.go", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The path of a nested list item consists of the key of the parent item followed by the key of the nested item
+	re := regexp.MustCompile(`"%v/outer=%v/inner=%v", state\.getPath\(\), [^\n]*\bstateKeyValues[^\n]*\bcstateKeyValues`)
+	if !re.Match(output) {
+		t.Errorf("unexpected delete path of nested list items:\n%s", regexp.MustCompile(`.*inner=.*`).Find(output))
+	}
+}
+
+func TestGetFields(t *testing.T) {
+	attributes := []YamlConfigAttribute{
+		{YangName: "device", TfName: "device", Type: "String", Reference: true},
+		{YangName: "name", TfName: "name", Type: "String", Id: true},
+		{YangName: "address", TfName: "address", Type: "String", XPath: "state/address"},
+		{YangName: "password", TfName: "password", Type: "String", WriteOnly: true},
+		{YangName: "secondary", TfName: "secondaries", Type: "List", Attributes: []YamlConfigAttribute{
+			{YangName: "address", TfName: "address", Type: "String", Id: true},
+			{YangName: "mask", TfName: "mask", Type: "String"},
+		}},
+	}
+	want := "name;state/address;secondary(address;mask)"
+	if got := GetFields(attributes); got != want {
+		t.Errorf("GetFields() = %q, want %q", got, want)
+	}
+}
+
+func TestProcessConfigYangNotLoaded(t *testing.T) {
+	config := YamlConfig{
+		Name: "Test",
+		Path: "test-missing:tests/test=%v",
+		Attributes: []YamlConfigAttribute{
+			{YangName: "name", TfName: "name", Type: "String", Id: true, Example: "a"},
+		},
+	}
+	yangErr, err := processConfig(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if yangErr == nil {
+		t.Error("processConfig() of a definition without cached YANG model returned no YANG error")
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	lines := func(from, to int, replace map[int]string) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			if r, ok := replace[i]; ok {
+				b.WriteString(r + "\n")
+			} else {
+				fmt.Fprintf(&b, "%d\n", i)
+			}
+		}
+		return b.String()
+	}
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "context",
+			a:    lines(1, 10, nil),
+			b:    lines(1, 10, map[int]string{5: "five"}),
+			want: "@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "separate hunks",
+			a:    lines(1, 20, nil),
+			b:    lines(1, 20, map[int]string{2: "two", 18: "eighteen"}),
+			want: "@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n@@ -15,6 +15,6 @@\n 15\n 16\n 17\n-18\n+eighteen\n 19\n 20\n",
+		},
+		{
+			name: "merged hunks",
+			a:    lines(1, 10, nil),
+			b:    lines(1, 10, map[int]string{2: "two", 8: "eight"}),
+			want: "@@ -1,10 +1,10 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n 9\n 10\n",
+		},
+		{
+			name: "trailing newline",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			want: "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "empty file",
+			a:    "",
+			b:    "a\nb\n",
+			want: "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "deleted file content",
+			a:    "a\n",
+			b:    "",
+			want: "@@ -1,1 +0,0 @@\n-a\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := "--- a/file.go\n+++ b/file.go\n" + tt.want
+			if got := unifiedDiff("file.go", tt.a, tt.b); got != want {
+				t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadDefinition(t *testing.T) {
+	schema, err := loadSchema(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modules := map[string]bool{"tailf-ncs": true}
+	tests := []struct {
+		name       string
+		definition string
+		want       []string
+	}{
+		{
+			name: "valid",
+			definition: `---
+name: Test
+path: tailf-ncs:devices/device=%v
+doc_category: Devices
+`,
+		},
+		{
+			// the module is not checked as the definition is invalid
+			name: "invalid",
+			definition: `---
+name: Test
+doc_category: Devices
+attributes:
+  - yang_name: name
+    id: yes please
+  - tf_name: address
+`,
+			want: []string{
+				"%s:6:9: attributes.0.id: expected a boolean",
+				"%s:7:5: attributes.1.yang_name: required key missing",
+				"%s:2:1: path: required key missing",
+			},
+		},
+		{
+			name: "module not listed",
+			definition: `---
+name: Test
+path: tailf-ned-cisco-ios:hostname
+doc_category: Devices
+`,
+			want: []string{"test.yaml: module tailf-ned-cisco-ios not listed in ./gen/models.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(file, []byte(tt.definition), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, errs := loadDefinition(file, schema, modules)
+			want := make([]string, len(tt.want))
+			for i, w := range tt.want {
+				want[i] = strings.ReplaceAll(w, "%s", file)
+			}
+			if strings.Join(errs, "\n") != strings.Join(want, "\n") {
+				t.Errorf("loadDefinition() errors:\n%s\nwant:\n%s", strings.Join(errs, "\n"), strings.Join(want, "\n"))
+			}
+		})
+	}
+}
@@ -1,66 +1,287 @@
-// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
-// All rights reserved.
-//
-// Licensed under the Mozilla Public License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//     https://mozilla.org/MPL/2.0/
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-//
-// SPDX-License-Identifier: MPL-2.0
-
 //go:build ignore
 
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
-)
 
-var models = []string{}
+	"github.com/openconfig/goyang/pkg/yang"
+	"gopkg.in/yaml.v3"
+)
 
 const (
-	modelsPath = "./gen/models/"
+	modelsPath   = "./gen/models/"
+	manifestPath = "./gen/models.yaml"
 )
 
+type Manifest struct {
+	Roots  []Model `yaml:"roots"`
+	Models []Model `yaml:"models"`
+}
+
+type Model struct {
+	Name     string `yaml:"name"`
+	Revision string `yaml:"revision,omitempty"`
+	Source   string `yaml:"source"`
+	Sha256   string `yaml:"sha256,omitempty"`
+}
+
+// File name of a model in the cache, following RFC 7950 section 5.2
+func (m Model) fileName() string {
+	if m.Revision == "" {
+		return m.Name + ".yang"
+	}
+	return m.Name + "@" + m.Revision + ".yang"
+}
+
 func main() {
-	for _, model := range models {
-		f := strings.Split(model, "/")
-		path := filepath.Join(modelsPath, f[len(f)-1])
-		if _, err := os.Stat(path); err != nil {
-			err := downloadModel(path, model)
-			if err != nil {
-				panic(err)
-			}
-			fmt.Println("Downloaded model: " + path)
+	offline := flag.Bool("offline", os.Getenv("NSO_GEN_OFFLINE") != "", "only verify cached models, never fetch from source")
+	pin := flag.Bool("pin", false, "pin the root modules and their imports and includes from source and update the manifest")
+	flag.Parse()
+
+	manifestFile, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		log.Fatalf("Error reading manifest: %v", err)
+	}
+	manifest := Manifest{}
+	err = yaml.Unmarshal(manifestFile, &manifest)
+	if err != nil {
+		log.Fatalf("Error parsing manifest: %v", err)
+	}
+
+	if *pin {
+		models, err := pinModels(manifest.Roots)
+		if err != nil {
+			log.Fatalf("Error pinning models: %v", err)
+		}
+		manifest.Models = models
+		if err := writeManifest(manifestFile, manifest); err != nil {
+			log.Fatalf("Error writing manifest: %v", err)
 		}
 	}
+
+	pinned := make(map[string]bool)
+	for _, model := range manifest.Models {
+		pinned[model.Name] = true
+	}
+	for _, root := range manifest.Roots {
+		if !pinned[root.Name] {
+			fmt.Printf("%s: not pinned, definitions using it are generated without the YANG model, run \"go run gen/load_models.go -pin\" with the source available\n", root.Name)
+		}
+	}
+
+	var errors []string
+	for _, model := range manifest.Models {
+		if err := loadModel(model, *offline); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+	if len(errors) == 0 {
+		errors = checkModels(manifest.Models)
+	}
+	if len(errors) > 0 {
+		for _, e := range errors {
+			fmt.Println(e)
+		}
+		os.Exit(1)
+	}
 }
 
-func downloadModel(filepath string, url string) error {
-	resp, err := http.Get(url)
-	if err != nil {
+// Resolve the root modules and all modules they import or include from source. Dependencies are looked up next to
+// the module importing them, as <name>.yang or the latest <name>@<revision>.yang of a local directory.
+func pinModels(roots []Model) ([]Model, error) {
+	var models []Model
+	seen := make(map[string]bool)
+	queue := append([]Model{}, roots...)
+	for len(queue) > 0 {
+		model := queue[0]
+		queue = queue[1:]
+		if seen[model.Name] {
+			continue
+		}
+		seen[model.Name] = true
+		source, data, err := fetchDependency(model.Source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", model.Name, err)
+		}
+		revision, dependencies, err := moduleInfo(data, source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", model.Name, err)
+		}
+		models = append(models, Model{Name: model.Name, Revision: revision, Source: source, Sha256: checksum(data)})
+		for _, dependency := range dependencies {
+			queue = append(queue, Model{Name: dependency, Source: dependencySource(source, dependency)})
+		}
+		fmt.Println("Pinned model: " + model.Name + "@" + revision)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	return models, nil
+}
+
+// Source of a dependency in the same location as the module importing it
+func dependencySource(source, name string) string {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return source[:strings.LastIndex(source, "/")+1] + name + ".yang"
+	}
+	return filepath.Join(filepath.Dir(source), name+".yang")
+}
+
+// Fetch a model, a missing local <name>.yang falls back to the latest <name>@<revision>.yang in the same directory
+func fetchDependency(source string) (string, []byte, error) {
+	data, err := fetchModel(source)
+	if err == nil || strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return source, data, err
+	}
+	matches, _ := filepath.Glob(strings.TrimSuffix(os.ExpandEnv(source), ".yang") + "@*.yang")
+	if len(matches) == 0 {
+		return source, nil, err
+	}
+	sort.Strings(matches)
+	// keep environment variables in the source
+	source = filepath.Join(filepath.Dir(source), filepath.Base(matches[len(matches)-1]))
+	data, err = fetchModel(source)
+	return source, data, err
+}
+
+// Write the manifest, keeping the comments at the top of the file
+func writeManifest(original []byte, manifest Manifest) error {
+	var header []string
+	for _, line := range strings.Split(string(original), "\n") {
+		if line != "---" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		header = append(header, line)
+	}
+	output := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(output)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(manifest); err != nil {
 		return err
 	}
+	return ioutil.WriteFile(manifestPath, []byte(strings.Join(header, "\n")+"\n"+output.String()), 0644)
+}
+
+// Make sure a model is available in the cache and matches the pinned checksum, cached files
+// which do not match are fetched again unless offline
+func loadModel(model Model, offline bool) error {
+	path := filepath.Join(modelsPath, model.fileName())
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if checksum(data) == model.Sha256 {
+			return nil
+		}
+		if offline {
+			return fmt.Errorf("%s: checksum mismatch of cached file %s", model.Name, path)
+		}
+	} else if offline {
+		return fmt.Errorf("%s: cached file %s not found, run \"go run gen/load_models.go\" to fetch it", model.Name, path)
+	}
+
+	data, err := fetchModel(model.Source)
+	if err != nil {
+		return fmt.Errorf("%s: %v", model.Name, err)
+	}
+	if sum := checksum(data); sum != model.Sha256 {
+		return fmt.Errorf("%s: checksum mismatch of %s, expected %q, got %q", model.Name, model.Source, model.Sha256, sum)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("%s: %v", model.Name, err)
+	}
+	fmt.Println("Fetched model: " + path)
+	return nil
+}
+
+// Fetch a model from an URL or a local file, environment variables in local paths are expanded
+// (e.g. $NCS_DIR/src/ncs/yang/tailf-ncs.yang)
+func fetchModel(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return ioutil.ReadFile(os.ExpandEnv(source))
+	}
+	resp, err := http.Get(source)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s, status: %s", source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
 
-	out, err := os.Create(filepath)
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Latest revision and the names of all imported and included modules of a model
+func moduleInfo(data []byte, path string) (string, []string, error) {
+	statements, err := yang.Parse(string(data), path)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	defer out.Close()
+	revision := ""
+	var dependencies []string
+	for _, s := range statements {
+		for _, ss := range s.SubStatements() {
+			switch ss.Keyword {
+			case "revision":
+				// revision dates sort lexically
+				if revision == "" || ss.Argument > revision {
+					revision = ss.Argument
+				}
+			case "import", "include":
+				dependencies = append(dependencies, ss.Argument)
+			}
+		}
+	}
+	return revision, dependencies, nil
+}
 
-	_, err = io.Copy(out, resp.Body)
-	return err
+// Verify name and revision of all cached models and that every import and include can be resolved
+// from the cache
+func checkModels(models []Model) []string {
+	var errors []string
+	pinned := make(map[string]bool)
+	for _, model := range models {
+		pinned[model.Name] = true
+	}
+	for _, model := range models {
+		path := filepath.Join(modelsPath, model.fileName())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", model.Name, err))
+			continue
+		}
+		statements, err := yang.Parse(string(data), path)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", model.Name, err))
+			continue
+		}
+		for _, s := range statements {
+			if s.Argument != model.Name {
+				errors = append(errors, fmt.Sprintf("%s: %s contains %s %s", model.Name, path, s.Keyword, s.Argument))
+			}
+		}
+		revision, dependencies, _ := moduleInfo(data, path)
+		for _, dependency := range dependencies {
+			if !pinned[dependency] {
+				errors = append(errors, fmt.Sprintf("%s: dependency %s not found in manifest %s", model.Name, dependency, manifestPath))
+			}
+		}
+		if revision != model.Revision {
+			errors = append(errors, fmt.Sprintf("%s: revision %q in manifest does not match latest revision %q of %s", model.Name, model.Revision, revision, path))
+		}
+	}
+	return errors
 }
@@ -0,0 +1,11 @@
+{{- if hasReference .Attributes}}
+data "nso_{{snakeCase .Name}}s" "example" {
+{{- range  .Attributes}}
+{{- if .Reference}}
+  {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else}}{{.Example}}{{end}}
+{{- end}}
+{{- end}}
+}
+{{- else}}
+data "nso_{{snakeCase .Name}}s" "example" {}
+{{- end}}
@@ -1,7 +1,7 @@
 data "nso_{{snakeCase .Name}}" "example" {
 {{- range  .Attributes}}
 {{- if or .Id .Reference}}
-  {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+  {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 {{- end}}
 {{- end}}
 }
@@ -67,49 +67,58 @@ func (d *{{camelCase .Name}}DataSource) Schema(ctx context.Context, req datasour
 				Computed:            true,
 			},
 			{{- range  .Attributes}}
-			"{{.TfName}}": schema.{{if eq .Type "List"}}ListNested{{else if or (eq .Type "StringList") (eq .Type "Int64List")}}List{{else}}{{.Type}}{{end}}Attribute{
+			"{{.TfName}}": schema.{{schemaType .Type}}Attribute{
 				MarkdownDescription: "{{.Description}}",
-				{{- if eq .Type "StringList"}}
-				ElementType:         types.StringType,
-				{{- else if eq .Type "Int64List"}}
-				ElementType:         types.Int64Type,
+				{{- if isLeafList .Type}}
+				ElementType:         types.{{elementType .Type}}Type,
 				{{- end}}
 				{{- if or .Id .Reference}}
 				Required:            true,
 				{{- else}}
 				Computed:            true,
 				{{- end}}
-				{{- if eq .Type "List"}}
+				{{- if .Sensitive}}
+				Sensitive:           true,
+				{{- end}}
+				{{- if isNested .Type}}
+				{{- $map := eq .Type "Map"}}
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						{{- range  .Attributes}}
-						"{{.TfName}}": schema.{{if eq .Type "List"}}ListNested{{else if or (eq .Type "StringList") (eq .Type "Int64List")}}List{{else}}{{.Type}}{{end}}Attribute{
+						{{- if not (and $map .Id)}}
+						"{{.TfName}}": schema.{{schemaType .Type}}Attribute{
 							MarkdownDescription: "{{.Description}}",
-							{{- if eq .Type "StringList"}}
-							ElementType:         types.StringType,
-							{{- else if eq .Type "Int64List"}}
-							ElementType:         types.Int64Type,
+							{{- if isLeafList .Type}}
+							ElementType:         types.{{elementType .Type}}Type,
 							{{- end}}
 							Computed:            true,
-							{{- if eq .Type "List"}}
+							{{- if .Sensitive}}
+							Sensitive:           true,
+							{{- end}}
+							{{- if isNested .Type}}
+							{{- $map := eq .Type "Map"}}
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									{{- range  .Attributes}}
-									"{{.TfName}}": schema.{{if or (eq .Type "StringList") (eq .Type "Int64List")}}List{{else}}{{.Type}}{{end}}Attribute{
+									{{- if not (and $map .Id)}}
+									"{{.TfName}}": schema.{{schemaType .Type}}Attribute{
 										MarkdownDescription: "{{.Description}}",
-										{{- if eq .Type "StringList"}}
-										ElementType:         types.StringType,
-										{{- else if eq .Type "Int64List"}}
-										ElementType:         types.Int64Type,
+										{{- if isLeafList .Type}}
+										ElementType:         types.{{elementType .Type}}Type,
 										{{- end}}
 										Computed:            true,
+										{{- if .Sensitive}}
+										Sensitive:           true,
+										{{- end}}
 									},
 									{{- end}}
+									{{- end}}
 								},
 							},
 							{{- end}}
 						},
 						{{- end}}
+						{{- end}}
 					},
 				},
 				{{- end}}
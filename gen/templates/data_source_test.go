@@ -30,7 +30,7 @@ import (
 func TestAccDataSourceNso{{camelCase .Name}}(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: {{if .TestPrerequisites}}testAccDataSourceNso{{camelCase .Name}}PrerequisitesConfig+{{end}}testAccDataSourceNso{{camelCase .Name}}Config,
@@ -46,16 +46,56 @@ func TestAccDataSourceNso{{camelCase .Name}}(t *testing.T) {
 					{{- $clist := .TfName }}
 					{{- range  .Attributes}}
 					{{- if and (not .WriteOnly) (not .ExcludeTest)}}
-					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{$clist}}.0.{{.TfName}}{{if or (eq .Type "StringList") (eq .Type "Int64List")}}.0{{end}}", "{{.Example}}"),
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{$clist}}.0.{{.TfName}}.*", "{{.Example}}"),
+					{{- else}}
+					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{$clist}}.0.{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
+					{{- end}}
+					{{- end}}
+					{{- end}}
+					{{- else if eq .Type "Set"}}
+					resource.TestCheckTypeSetElemNestedAttrs("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}.*", map[string]string{
+						{{- range  .Attributes}}
+						{{- if and (not .WriteOnly) (not .ExcludeTest) (not (isNested .Type)) (not (isLeafList .Type))}}
+						"{{.TfName}}": "{{.Example}}",
+						{{- end}}
+						{{- end}}
+					}),
+					{{- else}}
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}.*", "{{.Example}}"),
+					{{- else}}
+					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
+					{{- end}}
+					{{- end}}
 					{{- end}}
 					{{- end}}
+					{{- else if eq .Type "Set"}}
+					resource.TestCheckTypeSetElemNestedAttrs("data.nso_{{snakeCase $name}}.test", "{{.TfName}}.*", map[string]string{
+						{{- range  .Attributes}}
+						{{- if and (not .WriteOnly) (not .ExcludeTest) (not (isNested .Type)) (not (isLeafList .Type))}}
+						"{{.TfName}}": "{{.Example}}",
+						{{- end}}
+						{{- end}}
+					}),
+					{{- else if eq .Type "Map"}}
+					{{- $list := .TfName }}
+					{{- $key := getKeyExample .Attributes }}
+					{{- range  .Attributes}}
+					{{- if and (not .Id) (not .WriteOnly) (not .ExcludeTest)}}
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.{{$key}}.{{.TfName}}.*", "{{.Example}}"),
 					{{- else}}
-					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}{{if or (eq .Type "StringList") (eq .Type "Int64List")}}.0{{end}}", "{{.Example}}"),
+					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{$list}}.{{$key}}.{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
 					{{- end}}
 					{{- end}}
 					{{- end}}
 					{{- else}}
-					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{.TfName}}{{if or (eq .Type "StringList") (eq .Type "Int64List")}}.0{{end}}", "{{.Example}}"),
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("data.nso_{{snakeCase $name}}.test", "{{.TfName}}.*", "{{.Example}}"),
+					{{- else}}
+					resource.TestCheckResourceAttr("data.nso_{{snakeCase $name}}.test", "{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
+					{{- end}}
 					{{- end}}
 					{{- end}}
 					{{- end}}
@@ -113,26 +153,36 @@ resource "nso_{{snakeCase $name}}" "test" {
 	{{- end}}
 	{{- range  .Attributes}}
 	{{- if not .ExcludeTest}}
-	{{- if eq .Type "List"}}
+	{{- if isNestedList .Type}}
 	{{.TfName}} = [{
 		{{- range  .Attributes}}
 		{{- if not .ExcludeTest}}
-		{{- if eq .Type "List"}}
+		{{- if isNestedList .Type}}
 		{{.TfName}} = [{
 			{{- range  .Attributes}}
 			{{- if not .ExcludeTest}}
-			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 			{{- end}}
 			{{- end}}
 		}]
 		{{- else}}
-		{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+		{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 		{{- end}}
 		{{- end}}
 		{{- end}}
 	}]
+	{{- else if eq .Type "Map"}}
+	{{.TfName}} = {
+		"{{getKeyExample .Attributes}}" = {
+			{{- range  .Attributes}}
+			{{- if and (not .Id) (not (isNested .Type)) (not .ExcludeTest)}}
+			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+			{{- end}}
+			{{- end}}
+		}
+	}
 	{{- else}}
-	{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+	{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 	{{- end}}
 	{{- end}}
 	{{- end}}
@@ -144,7 +194,7 @@ resource "nso_{{snakeCase $name}}" "test" {
 data "nso_{{snakeCase .Name}}" "test" {
 	{{- range .Attributes}}
 	{{- if or .Id .Reference}}
-	{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+	{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 	{{- end}}
 	{{- end}}
 	depends_on = [nso_{{snakeCase $name}}.test]
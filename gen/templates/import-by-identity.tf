@@ -0,0 +1,8 @@
+import {
+  to = nso_{{snakeCase .Name}}.example
+  identity = {
+{{- range getKeyAttributes .Attributes}}
+    {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else}}{{.Example}}{{end}}
+{{- end}}
+  }
+}
@@ -43,10 +43,12 @@ type {{camelCase .Name}} struct {
 	DeleteMode types.String `tfsdk:"delete_mode"`
 {{- end}}
 {{- range .Attributes}}
-{{- if eq .Type "List"}}
+{{- if isNestedList .Type}}
 	{{toGoName .TfName}} []{{$name}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
-{{- else if or (eq .Type "StringList") (eq .Type "Int64List")}}
-	{{toGoName .TfName}} types.List `tfsdk:"{{.TfName}}"`
+{{- else if eq .Type "Map"}}
+	{{toGoName .TfName}} map[string]{{$name}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
+{{- else if isLeafList .Type}}
+	{{toGoName .TfName}} types.{{collectionType .Type}} `tfsdk:"{{.TfName}}"`
 {{- else}}
 	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
 {{- end}}
@@ -57,25 +59,80 @@ type {{camelCase .Name}}Data struct {
 	Instance types.String `tfsdk:"instance"`
 	Id     types.String `tfsdk:"id"`
 {{- range .Attributes}}
-{{- if eq .Type "List"}}
+{{- if isNestedList .Type}}
 	{{toGoName .TfName}} []{{$name}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
-{{- else if or (eq .Type "StringList") (eq .Type "Int64List")}}
-	{{toGoName .TfName}} types.List `tfsdk:"{{.TfName}}"`
+{{- else if eq .Type "Map"}}
+	{{toGoName .TfName}} map[string]{{$name}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
+{{- else if isLeafList .Type}}
+	{{toGoName .TfName}} types.{{collectionType .Type}} `tfsdk:"{{.TfName}}"`
 {{- else}}
 	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
 {{- end}}
 {{- end}}
 }
 
+{{- if .ListDataSource}}
+
+type {{camelCase .Name}}s struct {
+	Instance types.String `tfsdk:"instance"`
+	Id     types.String `tfsdk:"id"`
+{{- range .Attributes}}
+{{- if or .Id .Reference}}
+	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
+{{- end}}
+{{- end}}
+	Items []{{camelCase .Name}}sItem `tfsdk:"{{snakeCase .Name}}s"`
+}
+
+type {{camelCase .Name}}sItem struct {
+{{- range .Attributes}}
+{{- if not .Reference}}
+{{- if isNestedList .Type}}
+	{{toGoName .TfName}} []{{$name}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
+{{- else if eq .Type "Map"}}
+	{{toGoName .TfName}} map[string]{{$name}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
+{{- else if isLeafList .Type}}
+	{{toGoName .TfName}} types.{{collectionType .Type}} `tfsdk:"{{.TfName}}"`
+{{- else}}
+	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
+{{- end}}
+{{- end}}
+{{- end}}
+}
+{{- end}}
+
+{{- if hasId .Attributes}}
+
+type {{camelCase .Name}}Identity struct {
+	Instance types.String `tfsdk:"instance"`
+{{- range getKeyAttributes .Attributes}}
+	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
+{{- end}}
+}
+{{- end}}
+
+{{- if .ListDataSource}}
+
+type {{camelCase .Name}}sList struct {
+	Instance types.String `tfsdk:"instance"`
+{{- range getKeyAttributes .Attributes}}
+	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
+{{- end}}
+}
+{{- end}}
+
 {{- range .Attributes}}
 {{- $cname := toGoName .TfName}}
-{{- if eq .Type "List"}}
+{{- $map := eq .Type "Map"}}
+{{- if isNested .Type}}
+
 type {{$name}}{{toGoName .TfName}} struct {
 {{- range .Attributes}}
-{{- if eq .Type "List"}}
+{{- if and $map .Id}}
+{{- else if isNestedList .Type}}
 	{{toGoName .TfName}} []{{$name}}{{$cname}}{{toGoName .TfName}} `tfsdk:"{{.TfName}}"`
-{{- else if or (eq .Type "StringList") (eq .Type "Int64List")}}
-	{{toGoName .TfName}} types.List `tfsdk:"{{.TfName}}"`
+{{- else if isLeafList .Type}}
+	{{toGoName .TfName}} types.{{collectionType .Type}} `tfsdk:"{{.TfName}}"`
 {{- else}}
 	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
 {{- end}}
@@ -86,13 +143,14 @@ type {{$name}}{{toGoName .TfName}} struct {
 
 {{- range .Attributes}}
 {{- $cname := toGoName .TfName}}
-{{- if eq .Type "List"}}
+{{- if isNestedList .Type}}
 {{- range .Attributes}}
-{{- if eq .Type "List"}}
+{{- if isNestedList .Type}}
+
 type {{$name}}{{$cname}}{{toGoName .TfName}} struct {
 {{- range .Attributes}}
-{{- if or (eq .Type "StringList") (eq .Type "Int64List")}}
-	{{toGoName .TfName}} types.List `tfsdk:"{{.TfName}}"`
+{{- if isLeafList .Type}}
+	{{toGoName .TfName}} types.{{collectionType .Type}} `tfsdk:"{{.TfName}}"`
 {{- else}}
 	{{toGoName .TfName}} types.{{.Type}} `tfsdk:"{{.TfName}}"`
 {{- end}}
@@ -105,7 +163,7 @@ type {{$name}}{{$cname}}{{toGoName .TfName}} struct {
 
 func (data {{camelCase .Name}}) getPath() string {
 {{- if hasId .Attributes}}
-	return fmt.Sprintf("{{.Path}}"{{range .Attributes}}{{if or .Id .Reference}}, url.QueryEscape(fmt.Sprintf("%v", data.{{toGoName .TfName}}.Value{{.Type}}())){{end}}{{end}})
+	return fmt.Sprintf("{{.Path}}"{{range .Attributes}}{{if or .Id .Reference}}, helpers.EncodeKey(fmt.Sprintf("%v", data.{{toGoName .TfName}}.Value{{.Type}}())){{end}}{{end}})
 {{- else}}
 	return "{{.Path}}"
 {{- end}}
@@ -113,7 +171,7 @@ func (data {{camelCase .Name}}) getPath() string {
 
 func (data {{camelCase .Name}}Data) getPath() string {
 {{- if hasId .Attributes}}
-	return fmt.Sprintf("{{.Path}}"{{range .Attributes}}{{if or .Id .Reference}}, url.QueryEscape(fmt.Sprintf("%v", data.{{toGoName .TfName}}.Value{{.Type}}())){{end}}{{end}})
+	return fmt.Sprintf("{{.Path}}"{{range .Attributes}}{{if or .Id .Reference}}, helpers.EncodeKey(fmt.Sprintf("%v", data.{{toGoName .TfName}}.Value{{.Type}}())){{end}}{{end}})
 {{- else}}
 	return "{{.Path}}"
 {{- end}}
@@ -130,15 +188,52 @@ func (data {{camelCase .Name}}) getPathShort() string {
 	return matches[1]
 }
 
+{{- if hasId .Attributes}}
+
+// The key values are taken from the RESTCONF path, as only the id is known after an import
+func (data {{camelCase .Name}}) getIdentity() {{camelCase .Name}}Identity {
+	identity := {{camelCase .Name}}Identity{Instance: data.Instance}
+	keys := helpers.PathKeys("{{.Path}}", data.Id.ValueString())
+	if keys == nil {
+		{{- range getKeyAttributes .Attributes}}
+		identity.{{toGoName .TfName}} = data.{{toGoName .TfName}}
+		{{- end}}
+		return identity
+	}
+	{{- range $i, $a := getKeyAttributes .Attributes}}
+	{{- if eq .Type "Int64"}}
+	if value, err := strconv.ParseInt(keys[{{$i}}], 10, 64); err == nil {
+		identity.{{toGoName .TfName}} = types.Int64Value(value)
+	} else {
+		identity.{{toGoName .TfName}} = data.{{toGoName .TfName}}
+	}
+	{{- else}}
+	identity.{{toGoName .TfName}} = types.StringValue(keys[{{$i}}])
+	{{- end}}
+	{{- end}}
+	return identity
+}
+
+// Resource model with the key values of an identity, as used by an import with the identity attribute
+func (data {{camelCase .Name}}Identity) toResource() {{camelCase .Name}} {
+	return {{camelCase .Name}}{
+		Instance: data.Instance,
+		{{- range getKeyAttributes .Attributes}}
+		{{toGoName .TfName}}: data.{{toGoName .TfName}},
+		{{- end}}
+	}
+}
+{{- end}}
+
 func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 	body := `{"` + helpers.LastElement(data.getPath()) + `":{}}`
 	{{- range .Attributes}}
-	{{- if and (not .Reference) (ne .Type "List")}}
+	{{- if and (not .Reference) (not (isNested .Type))}}
 	if !data.{{toGoName .TfName}}.IsNull() && !data.{{toGoName .TfName}}.IsUnknown() {
 		{{- if eq .Type "Int64"}}
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatInt(data.{{toGoName .TfName}}.ValueInt64(), 10))
 		{{- else if eq .Type "Float64"}}
-		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(data.{{toGoName .TfName}}.ValueFloat64(), 'f', 1, 64))
+		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(data.{{toGoName .TfName}}.ValueFloat64(), 'f', {{if .FractionDigits}}{{.FractionDigits}}{{else}}-1{{end}}, 64))
 		{{- else if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
 		if data.{{toGoName .TfName}}.ValueBool() {
 			body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", map[string]string{})
@@ -147,12 +242,8 @@ func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", data.{{toGoName .TfName}}.ValueBool())
 		{{- else if eq .Type "String"}}
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", data.{{toGoName .TfName}}.ValueString())
-		{{- else if eq .Type "StringList"}}
-		var values []string
-		data.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
-		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", values)
-		{{- else if eq .Type "Int64List"}}
-		var values []int
+		{{- else if isLeafList .Type}}
+		var values []{{elementGoType .Type}}
 		data.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", values)
 		{{- end}}
@@ -160,32 +251,28 @@ func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 	{{- end}}
 	{{- end}}
 	{{- range .Attributes}}
-	{{- if eq .Type "List"}}
+	{{- if isNestedList .Type}}
 	{{- $list := toJsonPath .YangName .XPath }}
 	if len(data.{{toGoName .TfName}}) > 0 {
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", []interface{}{})
 		for index, item := range data.{{toGoName .TfName}} {
 			{{- range .Attributes}}
-			{{- if (ne .Type "List")}}
+			{{- if (not (isNested .Type))}}
 			if !item.{{toGoName .TfName}}.IsNull() && !item.{{toGoName .TfName}}.IsUnknown() {
 				{{- if eq .Type "Int64"}}
 				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatInt(item.{{toGoName .TfName}}.ValueInt64(), 10))
 				{{- else if eq .Type "Float64"}}
-				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(item.{{toGoName .TfName}}.ValueFloat64(), 'f', 1, 64))
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(item.{{toGoName .TfName}}.ValueFloat64(), 'f', {{if .FractionDigits}}{{.FractionDigits}}{{else}}-1{{end}}, 64))
 				{{- else if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
 				if item.{{toGoName .TfName}}.ValueBool() {
 					body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", map[string]string{})
 				}
 				{{- else if and (eq .Type "Bool") (eq .TypeYangBool "boolean")}}
-				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", (item.{{toGoName .TfName}}.ValueBool())
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", item.{{toGoName .TfName}}.ValueBool())
 				{{- else if eq .Type "String"}}
 				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", item.{{toGoName .TfName}}.ValueString())
-				{{- else if eq .Type "StringList"}}
-				var values []string
-				item.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
-				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", values)
-				{{- else if eq .Type "Int64List"}}
-				var values []int
+				{{- else if isLeafList .Type}}
+				var values []{{elementGoType .Type}}
 				item.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
 				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", values)
 				{{- end}}
@@ -193,7 +280,7 @@ func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 			{{- end}}
 			{{- end}}
 			{{- range .Attributes}}
-			{{- if eq .Type "List"}}
+			{{- if isNestedList .Type}}
 			{{- $clist := toJsonPath .YangName .XPath }}
 			if len(item.{{toGoName .TfName}}) > 0 {
 				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", []interface{}{})
@@ -203,7 +290,7 @@ func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 						{{- if eq .Type "Int64"}}
 						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatInt(citem.{{toGoName .TfName}}.ValueInt64(), 10))
 						{{- else if eq .Type "Float64"}}
-						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(item.{{toGoName .TfName}}.ValueFloat64(), 'f', 1, 64))
+						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(citem.{{toGoName .TfName}}.ValueFloat64(), 'f', {{if .FractionDigits}}{{.FractionDigits}}{{else}}-1{{end}}, 64))
 						{{- else if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
 						if citem.{{toGoName .TfName}}.ValueBool() {
 							body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", map[string]string{})
@@ -212,12 +299,8 @@ func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", citem.{{toGoName .TfName}}.ValueBool())
 						{{- else if eq .Type "String"}}
 						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", citem.{{toGoName .TfName}}.ValueString())
-						{{- else if eq .Type "StringList"}}
-						var values []string
-						citem.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
-						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", values)
-						{{- else if eq .Type "Int64List"}}
-						var values []int
+						{{- else if isLeafList .Type}}
+						var values []{{elementGoType .Type}}
 						citem.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
 						body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{$clist}}"+"."+strconv.Itoa(cindex)+"."+"{{toJsonPath .YangName .XPath}}", values)
 						{{- end}}
@@ -231,6 +314,45 @@ func (data {{camelCase .Name}}) toBody(ctx context.Context) string {
 	}
 	{{- end}}
 	{{- end}}
+	{{- range .Attributes}}
+	{{- if eq .Type "Map"}}
+	{{- $list := toJsonPath .YangName .XPath }}
+	if len(data.{{toGoName .TfName}}) > 0 {
+		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{toJsonPath .YangName .XPath}}", []interface{}{})
+		for index, key := range helpers.SortedKeys(data.{{toGoName .TfName}}) {
+			item := data.{{toGoName .TfName}}[key]
+			{{- range .Attributes}}
+			{{- if .Id}}
+			body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", key)
+			{{- end}}
+			{{- end}}
+			{{- range .Attributes}}
+			{{- if and (not .Id) (not (isNested .Type))}}
+			if !item.{{toGoName .TfName}}.IsNull() && !item.{{toGoName .TfName}}.IsUnknown() {
+				{{- if eq .Type "Int64"}}
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatInt(item.{{toGoName .TfName}}.ValueInt64(), 10))
+				{{- else if eq .Type "Float64"}}
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", strconv.FormatFloat(item.{{toGoName .TfName}}.ValueFloat64(), 'f', {{if .FractionDigits}}{{.FractionDigits}}{{else}}-1{{end}}, 64))
+				{{- else if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
+				if item.{{toGoName .TfName}}.ValueBool() {
+					body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", map[string]string{})
+				}
+				{{- else if and (eq .Type "Bool") (eq .TypeYangBool "boolean")}}
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", item.{{toGoName .TfName}}.ValueBool())
+				{{- else if eq .Type "String"}}
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", item.{{toGoName .TfName}}.ValueString())
+				{{- else if isLeafList .Type}}
+				var values []{{elementGoType .Type}}
+				item.{{toGoName .TfName}}.ElementsAs(ctx, &values, false)
+				body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"{{$list}}"+"."+strconv.Itoa(index)+"."+"{{toJsonPath .YangName .XPath}}", values)
+				{{- end}}
+			}
+			{{- end}}
+			{{- end}}
+		}
+	}
+	{{- end}}
+	{{- end}}
 	return body
 }
 
@@ -275,19 +397,78 @@ func (data *{{camelCase .Name}}) updateFromBody(ctx context.Context, res gjson.R
 	} else {
 		data.{{toGoName .TfName}} = types.StringNull()
 	}
-	{{- else if eq .Type "StringList"}}
+	{{- else if isLeafList .Type}}
 	if value := res.Get(prefix+"{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{toGoName .TfName}}.IsNull() {
-		data.{{toGoName .TfName}} = helpers.GetStringList(value.Array())
+		data.{{toGoName .TfName}} = helpers.Get{{.Type}}(value.Array())
 	} else {
-		data.{{toGoName .TfName}} = types.ListNull(types.StringType)
+		data.{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
 	}
-	{{- else if eq .Type "Int64List"}}
-	if value := res.Get(prefix+"{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{toGoName .TfName}}.IsNull() {
-		data.{{toGoName .TfName}} = helpers.GetInt64List(value.Array())
-	} else {
-		data.{{toGoName .TfName}} = types.ListNull(types.Int64Type)
+	{{- else if eq .Type "Map"}}
+	{{- $list := (toGoName .TfName)}}
+	{{- $listPath := (toJsonPath .YangName .XPath)}}
+	for key, item := range data.{{$list}} {
+		var r gjson.Result
+		res.Get(prefix+"{{$listPath}}").ForEach(
+			func(_, v gjson.Result) bool {
+				if v.Get("{{range .Attributes}}{{if .Id}}{{getXPath .YangName .XPath}}{{end}}{{end}}").String() == key {
+					r = v
+					return false
+				}
+				return true
+			},
+		)
+		if !r.Exists() {
+			delete(data.{{$list}}, key)
+			continue
+		}
+		{{- range .Attributes}}
+		{{- if and (not .Id) (not .WriteOnly)}}
+		{{- if eq .Type "Int64"}}
+		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !item.{{toGoName .TfName}}.IsNull() {
+			item.{{toGoName .TfName}} = types.Int64Value(value.Int())
+		} else {
+			item.{{toGoName .TfName}} = types.Int64Null()
+		}
+		{{- else if eq .Type "Float64"}}
+		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !item.{{toGoName .TfName}}.IsNull() {
+			item.{{toGoName .TfName}} = types.Float64Value(value.Float())
+		} else {
+			item.{{toGoName .TfName}} = types.Float64Null()
+		}
+		{{- else if eq .Type "Bool"}}
+		if value := r.Get("{{toJsonPath .YangName .XPath}}"); !item.{{toGoName .TfName}}.IsNull() {
+			{{- if eq .TypeYangBool "boolean"}}
+			if value.Exists() {
+				item.{{toGoName .TfName}} = types.BoolValue(value.Bool())
+			}
+			{{- else}}
+			if value.Exists() {
+				item.{{toGoName .TfName}} = types.BoolValue(true)
+			} else {
+				item.{{toGoName .TfName}} = types.BoolValue(false)
+			}
+			{{- end}}
+		} else {
+			item.{{toGoName .TfName}} = types.BoolNull()
+		}
+		{{- else if eq .Type "String"}}
+		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !item.{{toGoName .TfName}}.IsNull() {
+			item.{{toGoName .TfName}} = types.StringValue(value.String())
+		} else {
+			item.{{toGoName .TfName}} = types.StringNull()
+		}
+		{{- else if isLeafList .Type}}
+		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !item.{{toGoName .TfName}}.IsNull() {
+			item.{{toGoName .TfName}} = helpers.Get{{.Type}}(value.Array())
+		} else {
+			item.{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
+		}
+		{{- end}}
+		{{- end}}
+		{{- end}}
+		data.{{$list}}[key] = item
 	}
-	{{- else if eq .Type "List"}}
+	{{- else if isNestedList .Type}}
 	{{- $list := (toGoName .TfName)}}
 	{{- $listPath := (toJsonPath .YangName .XPath)}}
 	for i := range data.{{$list}} {
@@ -326,7 +507,7 @@ func (data *{{camelCase .Name}}) updateFromBody(ctx context.Context, res gjson.R
 		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{$list}}[i].{{toGoName .TfName}}.IsNull() {
 			data.{{$list}}[i].{{toGoName .TfName}} = types.Float64Value(value.Float())
 		} else {
-			data.{{$list}}[i].{{toGoName .TfName}} = type.Float64Null()
+			data.{{$list}}[i].{{toGoName .TfName}} = types.Float64Null()
 		}
 		{{- else if eq .Type "Bool"}}
 		if value := r.Get("{{toJsonPath .YangName .XPath}}"); !data.{{$list}}[i].{{toGoName .TfName}}.IsNull() {
@@ -350,19 +531,13 @@ func (data *{{camelCase .Name}}) updateFromBody(ctx context.Context, res gjson.R
 		} else {
 			data.{{$list}}[i].{{toGoName .TfName}} = types.StringNull()
 		}
-		{{- else if eq .Type "StringList"}}
-		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{$list}}[i].{{toGoName .TfName}}.IsNull() {
-			data.{{$list}}[i].{{toGoName .TfName}} = helpers.GetStringList(value.Array())
-		} else {
-			data.{{$list}}[i].{{toGoName .TfName}} = types.ListNull(types.StringType)
-		}
-		{{- else if eq .Type "Int64List"}}
+		{{- else if isLeafList .Type}}
 		if value := r.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{$list}}[i].{{toGoName .TfName}}.IsNull() {
-			data.{{$list}}[i].{{toGoName .TfName}} = helpers.GetInt64List(value.Array())
+			data.{{$list}}[i].{{toGoName .TfName}} = helpers.Get{{.Type}}(value.Array())
 		} else {
-			data.{{$list}}[i].{{toGoName .TfName}} = types.ListNull(types.Int64Type)
+			data.{{$list}}[i].{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
 		}
-		{{- else if eq .Type "List"}}
+		{{- else if isNestedList .Type}}
 		{{- $clist := (toGoName .TfName)}}
 		{{- $clistPath := (toJsonPath .YangName .XPath)}}
 		for ci := range data.{{$list}}[i].{{$clist}} {
@@ -401,7 +576,7 @@ func (data *{{camelCase .Name}}) updateFromBody(ctx context.Context, res gjson.R
 			if value := cr.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.IsNull() {
 				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = types.Float64Value(value.Float())
 			} else {
-				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = type.Float64Null()
+				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = types.Float64Null()
 			}
 			{{- else if eq .Type "Bool"}}
 			if value := cr.Get("{{toJsonPath .YangName .XPath}}"); !data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.IsNull() {
@@ -425,17 +600,11 @@ func (data *{{camelCase .Name}}) updateFromBody(ctx context.Context, res gjson.R
 			} else {
 				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = types.StringNull()
 			}
-			{{- else if eq .Type "StringList"}}
-			if value := cr.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.IsNull() {
-				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = helpers.GetStringList(value.Array())
-			} else {
-				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = types.ListNull(types.StringType)
-			}
-			{{- else if eq .Type "Int64List"}}
+			{{- else if isLeafList .Type}}
 			if value := cr.Get("{{toJsonPath .YangName .XPath}}"); value.Exists() && !data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.IsNull() {
-				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = helpers.GetInt64List(value.Array())
+				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = helpers.Get{{.Type}}(value.Array())
 			} else {
-				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = types.ListNull(types.Int64Type)
+				data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
 			}
 			{{- end}}
 			{{- end}}
@@ -480,19 +649,55 @@ func (data *{{camelCase .Name}}Data) fromBody(ctx context.Context, res gjson.Res
 	if value := res.Get(prefix+"{{toJsonPath .YangName .XPath}}"); value.Exists() {
 		data.{{toGoName .TfName}} = types.StringValue(value.String())
 	}
-	{{- else if eq .Type "StringList"}}
+	{{- else if isLeafList .Type}}
 	if value := res.Get(prefix+"{{toJsonPath .YangName .XPath}}"); value.Exists() {
-		data.{{toGoName .TfName}} = helpers.GetStringList(value.Array())
+		data.{{toGoName .TfName}} = helpers.Get{{.Type}}(value.Array())
 	} else {
-		data.{{toGoName .TfName}} = types.ListNull(types.StringType)
+		data.{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
 	}
-	{{- else if eq .Type "Int64List"}}
+	{{- else if eq .Type "Map"}}
 	if value := res.Get(prefix+"{{toJsonPath .YangName .XPath}}"); value.Exists() {
-		data.{{toGoName .TfName}} = helpers.GetInt64List(value.Array())
-	} else {
-		data.{{toGoName .TfName}} = types.ListNull(types.Int64Type)
+		data.{{toGoName .TfName}} = make(map[string]{{$name}}{{toGoName .TfName}})
+		value.ForEach(func(k, v gjson.Result) bool {
+			item := {{$name}}{{toGoName .TfName}}{}
+			{{- range .Attributes}}
+			{{- if and (not .Id) (not .WriteOnly)}}
+			{{- if eq .Type "Int64"}}
+			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
+				item.{{toGoName .TfName}} = types.Int64Value(cValue.Int())
+			}
+			{{- else if eq .Type "Float64"}}
+			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
+				item.{{toGoName .TfName}} = types.Float64Value(cValue.Float())
+			}
+			{{- else if eq .Type "Bool"}}
+			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
+				{{- if eq .TypeYangBool "boolean"}}
+				item.{{toGoName .TfName}} = types.BoolValue(cValue.Bool())
+				{{- else}}
+				item.{{toGoName .TfName}} = types.BoolValue(true)
+				{{- end}}
+			} else {
+				item.{{toGoName .TfName}} = types.BoolValue(false)
+			}
+			{{- else if eq .Type "String"}}
+			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
+				item.{{toGoName .TfName}} = types.StringValue(cValue.String())
+			}
+			{{- else if isLeafList .Type}}
+			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
+				item.{{toGoName .TfName}} = helpers.Get{{.Type}}(cValue.Array())
+			} else {
+				item.{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
+			}
+			{{- end}}
+			{{- end}}
+			{{- end}}
+			data.{{toGoName .TfName}}[v.Get("{{range .Attributes}}{{if .Id}}{{getXPath .YangName .XPath}}{{end}}{{end}}").String()] = item
+			return true
+		})
 	}
-	{{- else if eq .Type "List"}}
+	{{- else if isNestedList .Type}}
 	if value := res.Get(prefix+"{{toJsonPath .YangName .XPath}}"); value.Exists() {
 		data.{{toGoName .TfName}} = make([]{{$name}}{{toGoName .TfName}}, 0)
 		value.ForEach(func(k, v gjson.Result) bool {
@@ -521,19 +726,13 @@ func (data *{{camelCase .Name}}Data) fromBody(ctx context.Context, res gjson.Res
 			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
 				item.{{toGoName .TfName}} = types.StringValue(cValue.String())
 			}
-			{{- else if eq .Type "StringList"}}
-			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
-				item.{{toGoName .TfName}} = helpers.GetStringList(cValue.Array())
-			} else {
-				item.{{toGoName .TfName}} = types.ListNull(types.StringType)
-			}
-			{{- else if eq .Type "Int64List"}}
+			{{- else if isLeafList .Type}}
 			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
-				item.{{toGoName .TfName}} = helpers.GetInt64List(cValue.Array())
+				item.{{toGoName .TfName}} = helpers.Get{{.Type}}(cValue.Array())
 			} else {
-				item.{{toGoName .TfName}} = types.ListNull(types.Int64Type)
+				item.{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
 			}
-			{{- else if eq .Type "List"}}
+			{{- else if isNestedList .Type}}
 			if cValue := v.Get("{{toJsonPath .YangName .XPath}}"); cValue.Exists() {
 				item.{{toGoName .TfName}} = make([]{{$name}}{{$cname}}{{toGoName .TfName}}, 0)
 				cValue.ForEach(func(ck, cv gjson.Result) bool {
@@ -562,17 +761,11 @@ func (data *{{camelCase .Name}}Data) fromBody(ctx context.Context, res gjson.Res
 					if ccValue := cv.Get("{{toJsonPath .YangName .XPath}}"); ccValue.Exists() {
 						cItem.{{toGoName .TfName}} = types.StringValue(ccValue.String())
 					}
-					{{- else if eq .Type "StringList"}}
-					if ccValue := cv.Get("{{toJsonPath .YangName .XPath}}"); ccValue.Exists() {
-						cItem.{{toGoName .TfName}} = helpers.GetStringList(ccValue.Array())
-					} else {
-						cItem.{{toGoName .TfName}} = types.ListNull(types.StringType)
-					}
-					{{- else if eq .Type "Int64List"}}
+					{{- else if isLeafList .Type}}
 					if ccValue := cv.Get("{{toJsonPath .YangName .XPath}}"); ccValue.Exists() {
-						cItem.{{toGoName .TfName}} = helpers.GetInt64List(ccValue.Array())
+						cItem.{{toGoName .TfName}} = helpers.Get{{.Type}}(ccValue.Array())
 					} else {
-						cItem.{{toGoName .TfName}} = types.ListNull(types.Int64Type)
+						cItem.{{toGoName .TfName}} = types.{{collectionType .Type}}Null(types.{{elementType .Type}}Type)
 					}
 					{{- end}}
 					{{- end}}
@@ -593,12 +786,93 @@ func (data *{{camelCase .Name}}Data) fromBody(ctx context.Context, res gjson.Res
 	{{- end}}
 }
 
+{{- if .ListDataSource}}
+
+func (data {{camelCase .Name}}s) getPath() string {
+{{- if hasReference .Attributes}}
+	return fmt.Sprintf("{{getListPath .Path}}"{{range .Attributes}}{{if .Reference}}, helpers.EncodeKey(fmt.Sprintf("%v", data.{{toGoName .TfName}}.Value{{.Type}}())){{end}}{{end}})
+{{- else}}
+	return "{{getListPath .Path}}"
+{{- end}}
+}
+
+// Path of the list entry if all key filters are set, otherwise path of the list
+func (data {{camelCase .Name}}s) getReadPath() string {
+	{{- range .Attributes}}
+	{{- if .Id}}
+	if data.{{toGoName .TfName}}.IsNull() {
+		return data.getPath()
+	}
+	{{- end}}
+	{{- end}}
+	return fmt.Sprintf("{{.Path}}"{{range .Attributes}}{{if or .Id .Reference}}, helpers.EncodeKey(fmt.Sprintf("%v", data.{{toGoName .TfName}}.Value{{.Type}}())){{end}}{{end}})
+}
+
+func (data *{{camelCase .Name}}s) fromBody(ctx context.Context, res gjson.Result) {
+	data.Items = make([]{{camelCase .Name}}sItem, 0)
+	element := helpers.LastElement(data.getPath())
+	res.Get(element).ForEach(func(k, v gjson.Result) bool {
+		entry := {{camelCase .Name}}Data{}
+		entry.fromBody(ctx, gjson.Parse(`{"`+element+`":`+v.Raw+`}`))
+		item := {{camelCase .Name}}sItem{}
+		{{- range .Attributes}}
+		{{- if .Id}}
+		{{- if eq .Type "Int64"}}
+		item.{{toGoName .TfName}} = types.Int64Value(v.Get("{{toJsonPath .YangName .XPath}}").Int())
+		{{- else}}
+		item.{{toGoName .TfName}} = types.StringValue(v.Get("{{toJsonPath .YangName .XPath}}").String())
+		{{- end}}
+		if !data.{{toGoName .TfName}}.IsNull() && !data.{{toGoName .TfName}}.Equal(item.{{toGoName .TfName}}) {
+			return true
+		}
+		{{- else if not .Reference}}
+		item.{{toGoName .TfName}} = entry.{{toGoName .TfName}}
+		{{- end}}
+		{{- end}}
+		data.Items = append(data.Items, item)
+		return true
+	})
+}
+
+func (data {{camelCase .Name}}sList) getList() {{camelCase .Name}}s {
+	return {{camelCase .Name}}s{
+		Instance: data.Instance,
+		{{- range getKeyAttributes .Attributes}}
+		{{toGoName .TfName}}: data.{{toGoName .TfName}},
+		{{- end}}
+	}
+}
+
+// Resource models of all list entries, as read by the list resource
+func (data {{camelCase .Name}}sList) fromBody(ctx context.Context, res gjson.Result) []{{camelCase .Name}} {
+	list := data.getList()
+	list.fromBody(ctx, res)
+	resources := make([]{{camelCase .Name}}, 0, len(list.Items))
+	for _, item := range list.Items {
+		r := {{camelCase .Name}}{
+			Instance: data.Instance,
+			{{- range .Attributes}}
+			{{- if .Reference}}
+			{{toGoName .TfName}}: data.{{toGoName .TfName}},
+			{{- else}}
+			{{toGoName .TfName}}: item.{{toGoName .TfName}},
+			{{- end}}
+			{{- end}}
+		}
+		r.Id = types.StringValue(r.getPath())
+		resources = append(resources, r)
+	}
+	return resources
+}
+{{- end}}
+
 func (data *{{camelCase .Name}}) getDeletedListItems(ctx context.Context, state {{camelCase .Name}}) []string {
 	deletedListItems := make([]string, 0)
 	{{- range .Attributes}}
-	{{- if eq .Type "List"}}
+	{{- if isNestedList .Type}}
 	for i := range state.{{toGoName .TfName}} {
 		{{- $list := (toGoName .TfName)}}
+		{{- $listPath := (getXPath .YangName .XPath)}}
 		stateKeyValues := [...]string{ {{range .Attributes}}{{if .Id}}{{if eq .Type "Int64"}}strconv.FormatInt(state.{{$list}}[i].{{toGoName .TfName}}.ValueInt64(), 10), {{else if eq .Type "Bool"}}strconv.FormatBool(state.{{$list}}[i].{{toGoName .TfName}}.ValueBool()), {{else}}state.{{$list}}[i].{{toGoName .TfName}}.Value{{.Type}}(), {{end}}{{end}}{{end}} }
 		
 		emptyKeys := true
@@ -625,7 +899,7 @@ func (data *{{camelCase .Name}}) getDeletedListItems(ctx context.Context, state
 			{{- end}}
 			if found {
 				{{- range .Attributes}}
-				{{- if eq .Type "List"}}
+				{{- if isNestedList .Type}}
 				for ci := range state.{{$list}}[i].{{toGoName .TfName}} {
 					{{- $clist := (toGoName .TfName)}}
 					cstateKeyValues := [...]string{ {{range .Attributes}}{{if .Id}}{{if eq .Type "Int64"}}strconv.FormatInt(state.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.ValueInt64(), 10), {{else if eq .Type "Bool"}}strconv.FormatBool(state.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.ValueBool()), {{else}}state.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.Value{{.Type}}(), {{end}}{{end}}{{end}} }
@@ -657,7 +931,7 @@ func (data *{{camelCase .Name}}) getDeletedListItems(ctx context.Context, state
 						}
 					}
 					if !found {
-						deletedListItems = append(deletedListItems, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v/{{getXPath .YangName .XPath}}%v", state.getPath(), strings.Join(cstateKeyValues[:], ","), strings.Join(stateKeyValues[:], ",")))
+						deletedListItems = append(deletedListItems, fmt.Sprintf("%v/{{$listPath}}=%v/{{getXPath .YangName .XPath}}=%v", state.getPath(), helpers.EncodeKey(stateKeyValues[:]...), helpers.EncodeKey(cstateKeyValues[:]...)))
 					}
 				}
 				{{- end}}
@@ -666,7 +940,16 @@ func (data *{{camelCase .Name}}) getDeletedListItems(ctx context.Context, state
 			}
 		}
 		if !found {
-			deletedListItems = append(deletedListItems, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v", state.getPath(), strings.Join(stateKeyValues[:], ",")))
+			deletedListItems = append(deletedListItems, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v", state.getPath(), helpers.EncodeKey(stateKeyValues[:]...)))
+		}
+	}
+	{{- end}}
+	{{- end}}
+	{{- range .Attributes}}
+	{{- if eq .Type "Map"}}
+	for key := range state.{{toGoName .TfName}} {
+		if _, ok := data.{{toGoName .TfName}}[key]; !ok {
+			deletedListItems = append(deletedListItems, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v", state.getPath(), helpers.EncodeKey(key)))
 		}
 	}
 	{{- end}}
@@ -682,10 +965,10 @@ func (data *{{camelCase .Name}}) getEmptyLeafsDelete(ctx context.Context) []stri
 		emptyLeafsDelete = append(emptyLeafsDelete, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}", data.getPath()))
 	}
 	{{- end}}
-	{{- if eq .Type "List"}}
+	{{- if isNestedList .Type}}
 	{{- $hasEmpty := false}}
 	{{ range .Attributes}}{{ if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}{{ $hasEmpty = true}}{{ end}}{{- end}}
-	{{ range .Attributes}}{{if eq .Type "List"}}{{ range .Attributes}}{{ if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}{{ $hasEmpty = true}}{{ end}}{{end}}{{end}}{{- end}}
+	{{ range .Attributes}}{{if isNestedList .Type}}{{ range .Attributes}}{{ if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}{{ $hasEmpty = true}}{{ end}}{{end}}{{end}}{{- end}}
 	{{- if $hasEmpty}}
 	{{- $yangName := getXPath .YangName .XPath}}
 	for i := range data.{{toGoName .TfName}} {
@@ -694,10 +977,10 @@ func (data *{{camelCase .Name}}) getEmptyLeafsDelete(ctx context.Context) []stri
 		{{- range .Attributes}}
 		{{- if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
 		if !data.{{$list}}[i].{{toGoName .TfName}}.IsNull() && !data.{{$list}}[i].{{toGoName .TfName}}.ValueBool() {
-			emptyLeafsDelete = append(emptyLeafsDelete, fmt.Sprintf("%v/{{$yangName}}=%v/{{getXPath .YangName .XPath}}", data.getPath(), strings.Join(keyValues[:], ",")))
+			emptyLeafsDelete = append(emptyLeafsDelete, fmt.Sprintf("%v/{{$yangName}}=%v/{{getXPath .YangName .XPath}}", data.getPath(), helpers.EncodeKey(keyValues[:]...)))
 		}
 		{{- end}}
-		{{- if eq .Type "List"}}
+		{{- if isNestedList .Type}}
 		{{- $hasEmpty := false}}
 		{{ range .Attributes}}{{ if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}{{ $hasEmpty = true}}{{ end}}{{- end}}
 		{{- if $hasEmpty}}
@@ -708,7 +991,7 @@ func (data *{{camelCase .Name}}) getEmptyLeafsDelete(ctx context.Context) []stri
 			{{- range .Attributes}}
 			{{- if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
 			if !data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.IsNull() && !data.{{$list}}[i].{{$clist}}[ci].{{toGoName .TfName}}.ValueBool() {
-				emptyLeafsDelete = append(emptyLeafsDelete, fmt.Sprintf("%v/{{$yangName}}=%v/{{$cyangName}}=%v/{{getXPath .YangName .XPath}}", data.getPath(), strings.Join(keyValues[:], ","), strings.Join(ckeyValues[:], ",")))
+				emptyLeafsDelete = append(emptyLeafsDelete, fmt.Sprintf("%v/{{$yangName}}=%v/{{$cyangName}}=%v/{{getXPath .YangName .XPath}}", data.getPath(), helpers.EncodeKey(keyValues[:]...), helpers.EncodeKey(ckeyValues[:]...)))
 			}
 			{{- end}}
 			{{- end}}
@@ -720,13 +1003,28 @@ func (data *{{camelCase .Name}}) getEmptyLeafsDelete(ctx context.Context) []stri
 	{{- end}}
 	{{- end}}
 	{{- end}}
+	{{- range .Attributes}}
+	{{- if eq .Type "Map"}}
+	{{- $list := (toGoName .TfName)}}
+	{{- $yangName := getXPath .YangName .XPath}}
+	{{- range .Attributes}}
+	{{- if and (eq .Type "Bool") (ne .TypeYangBool "boolean")}}
+	for key, item := range data.{{$list}} {
+		if !item.{{toGoName .TfName}}.IsNull() && !item.{{toGoName .TfName}}.ValueBool() {
+			emptyLeafsDelete = append(emptyLeafsDelete, fmt.Sprintf("%v/{{$yangName}}=%v/{{getXPath .YangName .XPath}}", data.getPath(), helpers.EncodeKey(key)))
+		}
+	}
+	{{- end}}
+	{{- end}}
+	{{- end}}
+	{{- end}}
 	return emptyLeafsDelete
 }
 
 func (data *{{camelCase .Name}}) getDeletePaths(ctx context.Context) []string {
 	var deletePaths []string
 	{{- range .Attributes}}
-	{{- if and (not .Reference) (not .Id) (ne .Type "List") (not .NoDelete)}}
+	{{- if and (not .Reference) (not .Id) (not (isNested .Type)) (not .NoDelete)}}
 	if !data.{{toGoName .TfName}}.IsNull() {
 		{{- if .DeleteParent}}
 		deletePaths = append(deletePaths, fmt.Sprintf("%v/{{removeLastPathElement (getXPath .YangName .XPath)}}", data.getPath()))
@@ -734,12 +1032,16 @@ func (data *{{camelCase .Name}}) getDeletePaths(ctx context.Context) []string {
 		deletePaths = append(deletePaths, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}", data.getPath()))
 		{{- end}}
 	}
-	{{- else if and (eq .Type "List") (not .NoDelete)}}
+	{{- else if and (isNestedList .Type) (not .NoDelete)}}
 	for i := range data.{{toGoName .TfName}} {
 		{{- $list := (toGoName .TfName)}}
 		keyValues := [...]string{ {{range .Attributes}}{{if .Id}}{{if eq .Type "Int64"}}strconv.FormatInt(data.{{$list}}[i].{{toGoName .TfName}}.ValueInt64(), 10), {{else if eq .Type "Bool"}}strconv.FormatBool(data.{{$list}}[i].{{toGoName .TfName}}.ValueBool()), {{else}}data.{{$list}}[i].{{toGoName .TfName}}.Value{{.Type}}(), {{end}}{{end}}{{end}} }
 
-		deletePaths = append(deletePaths, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v", data.getPath(), strings.Join(keyValues[:], ",")))
+		deletePaths = append(deletePaths, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v", data.getPath(), helpers.EncodeKey(keyValues[:]...)))
+	}
+	{{- else if and (eq .Type "Map") (not .NoDelete)}}
+	for key := range data.{{toGoName .TfName}} {
+		deletePaths = append(deletePaths, fmt.Sprintf("%v/{{getXPath .YangName .XPath}}=%v", data.getPath(), helpers.EncodeKey(key)))
 	}
 	{{- end}}
 	{{- end}}
@@ -26,11 +26,16 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -38,11 +43,23 @@ import (
 	"github.com/netascode/go-restconf"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 )
 
+{{- if hasId .Attributes}}
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.ResourceWithIdentity = &{{camelCase .Name}}Resource{}
+var _ resource.ResourceWithMoveState = &{{camelCase .Name}}Resource{}
+
+{{ end -}}
 func New{{camelCase .Name}}Resource() resource.Resource {
 	return &{{camelCase .Name}}Resource{}
 }
@@ -82,7 +99,7 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 			},
 			{{- end}}
 			{{- range  .Attributes}}
-			"{{.TfName}}": schema.{{if eq .Type "List"}}ListNested{{else if or (eq .Type "StringList") (eq .Type "Int64List")}}List{{else}}{{.Type}}{{end}}Attribute{
+			"{{.TfName}}": schema.{{schemaType .Type}}Attribute{
 				MarkdownDescription: helpers.NewAttributeDescription("{{.Description}}")
 					{{- if len .EnumValues -}}
 					.AddStringEnumDescription({{range .EnumValues}}"{{.}}", {{end}})
@@ -90,14 +107,16 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 					{{- if or (ne .MinInt 0) (ne .MaxInt 0) -}}
 					.AddIntegerRangeDescription({{.MinInt}}, {{.MaxInt}})
 					{{- end -}}
+					{{- if or (ne .MinFloat 0.0) (ne .MaxFloat 0.0) -}}
+					.AddFloatRangeDescription({{.MinFloat}}, {{.MaxFloat}})
+					{{- end -}}
 					{{- if len .DefaultValue -}}
 					.AddDefaultValueDescription("{{.DefaultValue}}")
 					{{- end -}}
+					{{- template "constraintDescriptions" . -}}
 					.String,
-				{{- if eq .Type "StringList"}}
-				ElementType:         types.StringType,
-				{{- else if eq .Type "Int64List"}}
-				ElementType:         types.Int64Type,
+				{{- if isLeafList .Type}}
+				ElementType:         types.{{elementType .Type}}Type,
 				{{- end}}
 				{{- if or .Id .Reference .Mandatory}}
 				Required:            true,
@@ -107,45 +126,23 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 				{{- if len .DefaultValue}}
 				Computed:            true,
 				{{- end}}
-				{{- if len .EnumValues}}
-				Validators: []validator.String{
-					stringvalidator.OneOf({{range .EnumValues}}"{{.}}", {{end}}),
-				},
-				{{- else if or (len .StringPatterns) (ne .StringMinLength 0) (ne .StringMaxLength 0) }}
-				Validators: []validator.String{
-					{{- if or (ne .StringMinLength 0) (ne .StringMaxLength 0)}}
-					stringvalidator.LengthBetween({{.StringMinLength}}, {{.StringMaxLength}}),
-					{{- end}}
-					{{- range .StringPatterns}}
-					stringvalidator.RegexMatches(regexp.MustCompile(`{{.}}`), ""),
-					{{- end}}
-				},
-				{{- else if or (ne .MinInt 0) (ne .MaxInt 0)}}
-				Validators: []validator.Int64{
-					int64validator.Between({{.MinInt}}, {{.MaxInt}}),
-				},
-				{{- else if or (ne .MinFloat 0.0) (ne .MaxFloat 0.0)}}
-				Validators: []validator.Float64{
-					float64validator.Between({{.MinFloat}}, {{.MaxFloat}}),
-				},
+				{{- if .Sensitive}}
+				Sensitive:           true,
 				{{- end}}
+				{{- template "validators" .}}
 				{{- if or .Id .Reference .RequiresReplace}}
-				PlanModifiers: []planmodifier.{{.Type}}{
-					{{snakeCase .Type}}planmodifier.RequiresReplace(),
+				PlanModifiers: []planmodifier.{{planModifierType .Type}}{
+					{{snakeCase (planModifierType .Type)}}planmodifier.RequiresReplace(),
 				},
 				{{- end}}
-				{{- if and (len .DefaultValue) (eq .Type "Int64")}}
-				Default:             int64default.StaticInt64({{.DefaultValue}}),
-				{{- else if and (len .DefaultValue) (eq .Type "Bool")}}
-				Default:             booldefault.StaticBool({{.DefaultValue}}),
-				{{- else if and (len .DefaultValue) (eq .Type "String")}}
-				Default:             stringdefault.StaticString("{{.DefaultValue}}"),
-				{{- end}}
-				{{- if eq .Type "List"}}
+				{{- template "default" .}}
+				{{- if isNested .Type}}
+				{{- $map := eq .Type "Map"}}
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						{{- range  .Attributes}}
-						"{{.TfName}}": schema.{{if eq .Type "List"}}ListNested{{else if or (eq .Type "StringList") (eq .Type "Int64List")}}List{{else}}{{.Type}}{{end}}Attribute{
+						{{- if not (and $map .Id)}}
+						"{{.TfName}}": schema.{{schemaType .Type}}Attribute{
 							MarkdownDescription: helpers.NewAttributeDescription("{{.Description}}")
 								{{- if len .EnumValues -}}
 								.AddStringEnumDescription({{range .EnumValues}}"{{.}}", {{end}})
@@ -159,11 +156,10 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 								{{- if len .DefaultValue -}}
 								.AddDefaultValueDescription("{{.DefaultValue}}")
 								{{- end -}}
+								{{- template "constraintDescriptions" . -}}
 								.String,
-							{{- if eq .Type "StringList"}}
-							ElementType:         types.StringType,
-							{{- else if eq .Type "Int64List"}}
-							ElementType:         types.Int64Type,
+							{{- if isLeafList .Type}}
+							ElementType:         types.{{elementType .Type}}Type,
 							{{- end}}
 							{{- if or .Id .Mandatory}}
 							Required:            true,
@@ -173,36 +169,23 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 							{{- if len .DefaultValue}}
 							Computed:            true,
 							{{- end}}
-							{{- if len .EnumValues}}
-							Validators: []validator.String{
-								stringvalidator.OneOf({{range .EnumValues}}"{{.}}", {{end}}),
-							},
-							{{- else if or (len .StringPatterns) (ne .StringMinLength 0) (ne .StringMaxLength 0) }}
-							Validators: []validator.String{
-								{{- if or (ne .StringMinLength 0) (ne .StringMaxLength 0)}}
-								stringvalidator.LengthBetween({{.StringMinLength}}, {{.StringMaxLength}}),
-								{{- end}}
-								{{- range .StringPatterns}}
-								stringvalidator.RegexMatches(regexp.MustCompile(`{{.}}`), ""),
-								{{- end}}
-							},
-							{{- else if or (ne .MinInt 0) (ne .MaxInt 0)}}
-							Validators: []validator.Int64{
-								int64validator.Between({{.MinInt}}, {{.MaxInt}}),
-							},
+							{{- if .Sensitive}}
+							Sensitive:           true,
 							{{- end}}
-							{{- if and (len .DefaultValue) (eq .Type "Int64")}}
-							Default:             int64default.StaticInt64({{.DefaultValue}}),
-							{{- else if and (len .DefaultValue) (eq .Type "Bool")}}
-							Default:             booldefault.StaticBool({{.DefaultValue}}),
-							{{- else if and (len .DefaultValue) (eq .Type "String")}}
-							Default:             stringdefault.StaticString("{{.DefaultValue}}"),
+							{{- template "validators" .}}
+							{{- if .RequiresReplace}}
+							PlanModifiers: []planmodifier.{{planModifierType .Type}}{
+								{{snakeCase (planModifierType .Type)}}planmodifier.RequiresReplace(),
+							},
 							{{- end}}
-							{{- if eq .Type "List"}}
+							{{- template "default" .}}
+							{{- if isNested .Type}}
+							{{- $map := eq .Type "Map"}}
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									{{- range  .Attributes}}
-									"{{.TfName}}": schema.{{if or (eq .Type "StringList") (eq .Type "Int64List")}}List{{else}}{{.Type}}{{end}}Attribute{
+									{{- if not (and $map .Id)}}
+									"{{.TfName}}": schema.{{schemaType .Type}}Attribute{
 										MarkdownDescription: helpers.NewAttributeDescription("{{.Description}}")
 											{{- if len .EnumValues -}}
 											.AddStringEnumDescription({{range .EnumValues}}"{{.}}", {{end}})
@@ -216,11 +199,10 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 											{{- if len .DefaultValue -}}
 											.AddDefaultValueDescription("{{.DefaultValue}}")
 											{{- end -}}
+											{{- template "constraintDescriptions" . -}}
 											.String,
-										{{- if eq .Type "StringList"}}
-										ElementType:         types.StringType,
-										{{- else if eq .Type "Int64List"}}
-										ElementType:         types.Int64Type,
+										{{- if isLeafList .Type}}
+										ElementType:         types.{{elementType .Type}}Type,
 										{{- end}}
 										{{- if or .Id .Mandatory}}
 										Required:            true,
@@ -230,38 +212,25 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 										{{- if len .DefaultValue}}
 										Computed:            true,
 										{{- end}}
-										{{- if len .EnumValues}}
-										Validators: []validator.String{
-											stringvalidator.OneOf({{range .EnumValues}}"{{.}}", {{end}}),
-										},
-										{{- else if or (len .StringPatterns) (ne .StringMinLength 0) (ne .StringMaxLength 0) }}
-										Validators: []validator.String{
-											{{- if or (ne .StringMinLength 0) (ne .StringMaxLength 0)}}
-											stringvalidator.LengthBetween({{.StringMinLength}}, {{.StringMaxLength}}),
-											{{- end}}
-											{{- range .StringPatterns}}
-											stringvalidator.RegexMatches(regexp.MustCompile(`{{.}}`), ""),
-											{{- end}}
-										},
-										{{- else if or (ne .MinInt 0) (ne .MaxInt 0)}}
-										Validators: []validator.Int64{
-											int64validator.Between({{.MinInt}}, {{.MaxInt}}),
-										},
+										{{- if .Sensitive}}
+										Sensitive:           true,
 										{{- end}}
-										{{- if and (len .DefaultValue) (eq .Type "Int64")}}
-										Default:             int64default.StaticInt64({{.DefaultValue}}),
-										{{- else if and (len .DefaultValue) (eq .Type "Bool")}}
-										Default:             booldefault.StaticBool({{.DefaultValue}}),
-										{{- else if and (len .DefaultValue) (eq .Type "String")}}
-										Default:             stringdefault.StaticString("{{.DefaultValue}}"),
+										{{- template "validators" .}}
+										{{- if .RequiresReplace}}
+										PlanModifiers: []planmodifier.{{planModifierType .Type}}{
+											{{snakeCase (planModifierType .Type)}}planmodifier.RequiresReplace(),
+										},
 										{{- end}}
+										{{- template "default" .}}
 									},
 									{{- end}}
+									{{- end}}
 								},
 							},
 							{{- end}}
 						},
 						{{- end}}
+						{{- end}}
 					},
 				},
 				{{- end}}
@@ -271,6 +240,25 @@ func (r *{{camelCase .Name}}Resource) Schema(ctx context.Context, req resource.S
 	}
 }
 
+{{if hasId .Attributes}}
+func (r *{{camelCase .Name}}Resource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"instance": identityschema.StringAttribute{
+				Description:       "An instance name from the provider configuration.",
+				OptionalForImport: true,
+			},
+			{{- range getKeyAttributes .Attributes}}
+			"{{.TfName}}": identityschema.{{.Type}}Attribute{
+				Description:       "{{.Description}}",
+				RequiredForImport: true,
+			},
+			{{- end}}
+		},
+	}
+}
+
+{{ end -}}
 func (r *{{camelCase .Name}}Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -279,6 +267,49 @@ func (r *{{camelCase .Name}}Resource) Configure(_ context.Context, req resource.
 	r.clients = req.ProviderData.(map[string]*restconf.Client)
 }
 
+{{- if hasLeafref .Attributes}}
+
+// Check that entries referenced by leafrefs exist before anything is committed
+func (r *{{camelCase .Name}}Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state {{camelCase .Name}}
+
+	// Read plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Instance.IsUnknown() {
+		return
+	}
+
+	// Read state
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := r.clients[plan.Instance.ValueString()]
+	{{- range .Attributes}}
+	{{- if .Leafref}}
+	{{- if isDeviceLeafref .Leafref}}
+	if !plan.Device.IsUnknown() && (req.State.Raw.IsNull() || !plan.{{toGoName .TfName}}.Equal(state.{{toGoName .TfName}})) {
+		resp.Diagnostics.Append(helpers.CheckReferences(ctx, client, path.Root("{{.TfName}}"), plan.{{toGoName .TfName}}, "{{.Leafref}}", helpers.EncodeKey(plan.Device.ValueString()))...)
+	}
+	{{- else}}
+	if req.State.Raw.IsNull() || !plan.{{toGoName .TfName}}.Equal(state.{{toGoName .TfName}}) {
+		resp.Diagnostics.Append(helpers.CheckReferences(ctx, client, path.Root("{{.TfName}}"), plan.{{toGoName .TfName}}, "{{.Leafref}}")...)
+	}
+	{{- end}}
+	{{- end}}
+	{{- end}}
+}
+{{- end}}
+
 func (r *{{camelCase .Name}}Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan {{camelCase .Name}}
 
@@ -302,12 +333,15 @@ func (r *{{camelCase .Name}}Resource) Create(ctx context.Context, req resource.C
 	emptyLeafsDelete := plan.getEmptyLeafsDelete(ctx)
 	tflog.Debug(ctx, fmt.Sprintf("List of empty leafs to delete: %+v", emptyLeafsDelete))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+
 	if YangPatch {
 		edits := []restconf.YangPatchEdit{restconf.NewYangPatchEdit("merge", plan.getPath(), restconf.Body{Str: body})}
 		for _, i := range emptyLeafsDelete {
 			edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 		}
-		_, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		res, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object, got error: %s", err))
 			return
@@ -315,27 +349,40 @@ func (r *{{camelCase .Name}}Resource) Create(ctx context.Context, req resource.C
 	} else {
 		res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 		if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-			_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+			res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 		}
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 			return
 		}
 		for _, i := range emptyLeafsDelete {
 			res, err := r.clients[plan.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
 			}
 		}
 	}
-	
-	plan.Id = types.StringValue(plan.getPath())
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
+	plan.Id = types.StringValue(plan.getPath())
 
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+{{- if hasId .Attributes}}
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+{{- end}}
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
 }
 
 func (r *{{camelCase .Name}}Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -371,6 +418,11 @@ func (r *{{camelCase .Name}}Resource) Read(ctx context.Context, req resource.Rea
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+{{- if hasId .Attributes}}
+
+	diags = resp.Identity.Set(ctx, state.getIdentity())
+	resp.Diagnostics.Append(diags...)
+{{- end}}
 }
 
 func (r *{{camelCase .Name}}Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -405,6 +457,8 @@ func (r *{{camelCase .Name}}Resource) Update(ctx context.Context, req resource.U
 	emptyLeafsDelete := plan.getEmptyLeafsDelete(ctx)
 	tflog.Debug(ctx, fmt.Sprintf("List of empty leafs to delete: %+v", emptyLeafsDelete))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+
 	if YangPatch {
 		edits := []restconf.YangPatchEdit{restconf.NewYangPatchEdit("merge", plan.getPath(), restconf.Body{Str: body})}
 		for _, i := range deletedListItems {
@@ -413,7 +467,8 @@ func (r *{{camelCase .Name}}Resource) Update(ctx context.Context, req resource.U
 		for _, i := range emptyLeafsDelete {
 			edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 		}
-		_, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		res, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update object, got error: %s", err))
 			return
@@ -421,14 +476,16 @@ func (r *{{camelCase .Name}}Resource) Update(ctx context.Context, req resource.U
 	} else {
 		res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 		if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-			_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+			res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 		}
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 			return
 		}
 		for _, i := range deletedListItems {
 			res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -436,6 +493,7 @@ func (r *{{camelCase .Name}}Resource) Update(ctx context.Context, req resource.U
 		}
 		for _, i := range emptyLeafsDelete {
 			res, err := r.clients[plan.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -443,10 +501,21 @@ func (r *{{camelCase .Name}}Resource) Update(ctx context.Context, req resource.U
 		}
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.Id.ValueString()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+{{- if hasId .Attributes}}
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+{{- end}}
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.Id.ValueString()))
 }
 
 func (r *{{camelCase .Name}}Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -479,8 +548,10 @@ func (r *{{camelCase .Name}}Resource) Delete(ctx context.Context, req resource.D
 	}
 	{{- end}}
 
+	commitQueue := helpers.NewCommitQueue(r.clients[state.Instance.ValueString()])
 	if deleteMode == "all" {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(state.Id.ValueString())
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 && res.StatusCode != 400 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
@@ -494,7 +565,8 @@ func (r *{{camelCase .Name}}Resource) Delete(ctx context.Context, req resource.D
 			for _, i := range deletePaths {
 				edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 			}
-			_, err := r.clients[state.Instance.ValueString()].YangPatchData("", "1", "", edits)
+			res, err := r.clients[state.Instance.ValueString()].YangPatchData("", "1", "", edits)
+			commitQueue.Add(res)
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -502,6 +574,7 @@ func (r *{{camelCase .Name}}Resource) Delete(ctx context.Context, req resource.D
 		} else {
 			for _, i := range deletePaths {
 				res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+				commitQueue.Add(res)
 				if err != nil && res.StatusCode != 404 {
 					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 					return
@@ -509,6 +582,10 @@ func (r *{{camelCase .Name}}Resource) Delete(ctx context.Context, req resource.D
 			}
 		}
 	}
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Delete finished successfully", state.Id.ValueString()))
 
@@ -516,5 +593,169 @@ func (r *{{camelCase .Name}}Resource) Delete(ctx context.Context, req resource.D
 }
 
 func (r *{{camelCase .Name}}Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+{{- if hasId .Attributes}}
+	if req.ID != "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	var identity {{camelCase .Name}}Identity
+
+	// Read identity
+	diags := req.Identity.Get(ctx, &identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := identity.toResource()
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Import", state.getPath()))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), state.getPath())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), state.Instance)...)
+	{{- range getKeyAttributes .Attributes}}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("{{.TfName}}"), state.{{toGoName .TfName}})...)
+	{{- end}}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", state.getPath()))
+{{- else}}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+{{- end}}
+}
+
+{{- if hasId .Attributes}}
+
+func (r *{{camelCase .Name}}Resource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveRestconfState},
+	}
 }
+
+// An nso_restconf resource managing the same object can be migrated to this resource using a moved block
+func (r *{{camelCase .Name}}Resource) moveRestconfState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "nso_restconf" || !strings.HasSuffix(strings.ToLower(req.SourceProviderAddress), "/ciscodevnet/nso") {
+		return
+	}
+
+	source, diags := getMovedRestconf(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if helpers.PathKeys("{{.Path}}", source.Path.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Move Resource State", fmt.Sprintf("The path '%s' of the nso_restconf resource is not a path of the nso_{{snakeCase .Name}} resource.", source.Path.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Move", source.Path.ValueString()))
+
+	identity := {{camelCase .Name}}{Instance: source.Instance, Id: source.Path}.getIdentity()
+	state := identity.toResource()
+
+	var data {{camelCase .Name}}Data
+	data.fromBody(ctx, gjson.Parse(source.toBody(ctx)))
+	{{- range .Attributes}}
+	{{- if and (not .Id) (not .Reference)}}
+	state.{{toGoName .TfName}} = data.{{toGoName .TfName}}
+	{{- end}}
+	{{- end}}
+	state.Id = types.StringValue(state.getPath())
+
+	diags = resp.TargetState.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.TargetIdentity.Set(ctx, identity)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Move finished successfully", state.getPath()))
+}
+{{- end}}
+
+{{- define "constraintDescriptions" -}}
+{{- if len .ConflictsWith -}}
+.AddConflictsWithDescription({{range .ConflictsWith}}"{{.}}", {{end}})
+{{- end -}}
+{{- if len .ExactlyOneOf -}}
+.AddExactlyOneOfDescription({{range .ExactlyOneOf}}"{{.}}", {{end}})
+{{- end -}}
+{{- if len .AtLeastOneOf -}}
+.AddAtLeastOneOfDescription({{range .AtLeastOneOf}}"{{.}}", {{end}})
+{{- end -}}
+{{- if len .AlsoRequires -}}
+.AddAlsoRequiresDescription({{range .AlsoRequires}}"{{.}}", {{end}})
+{{- end -}}
+{{- if len .When -}}
+.AddWhenDescription(`{{.When}}`)
+{{- end -}}
+{{- range .Must -}}
+.AddMustDescription(`{{.}}`)
+{{- end -}}
+{{- if len .Leafref -}}
+.AddReferenceDescription("{{leafrefTarget .Leafref}}", "{{.LeafrefResource}}")
+{{- end -}}
+{{- end}}
+
+{{- define "default"}}
+{{- if len .DefaultValue}}
+{{- if eq .Type "Int64"}}
+Default: int64default.StaticInt64({{.DefaultValue}}),
+{{- else if eq .Type "Float64"}}
+Default: float64default.StaticFloat64({{.DefaultValue}}),
+{{- else if eq .Type "Bool"}}
+Default: booldefault.StaticBool({{.DefaultValue}}),
+{{- else if eq .Type "String"}}
+Default: stringdefault.StaticString("{{.DefaultValue}}"),
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- define "validators"}}
+{{- if hasValidators .}}
+Validators: []validator.{{validatorType .Type}}{
+	{{- if and (eq .Type "String") (len .EnumValues)}}
+	stringvalidator.OneOf({{range .EnumValues}}"{{.}}", {{end}}),
+	{{- else if eq .Type "String"}}
+	{{- if or (ne .StringMinLength 0) (ne .StringMaxLength 0)}}
+	stringvalidator.LengthBetween({{.StringMinLength}}, {{.StringMaxLength}}),
+	{{- end}}
+	{{- range .StringPatterns}}
+	stringvalidator.RegexMatches(regexp.MustCompile(`{{.}}`), ""),
+	{{- end}}
+	{{- else if and (eq .Type "Int64") (or (ne .MinInt 0) (ne .MaxInt 0))}}
+	int64validator.Between({{.MinInt}}, {{.MaxInt}}),
+	{{- else if and (eq .Type "Float64") (or (ne .MinFloat 0.0) (ne .MaxFloat 0.0))}}
+	float64validator.Between({{.MinFloat}}, {{.MaxFloat}}),
+	{{- else if and (isLeafList .Type) (eq (elementType .Type) "String") (len .EnumValues)}}
+	{{validatorPackage .Type}}.ValueStringsAre(stringvalidator.OneOf({{range .EnumValues}}"{{.}}", {{end}})),
+	{{- else if and (isLeafList .Type) (eq (elementType .Type) "String")}}
+	{{- if or (ne .StringMinLength 0) (ne .StringMaxLength 0)}}
+	{{validatorPackage .Type}}.ValueStringsAre(stringvalidator.LengthBetween({{.StringMinLength}}, {{.StringMaxLength}})),
+	{{- end}}
+	{{- range .StringPatterns}}
+	{{validatorPackage $.Type}}.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`{{.}}`), "")),
+	{{- end}}
+	{{- else if and (isLeafList .Type) (eq (elementType .Type) "Int64") (or (ne .MinInt 0) (ne .MaxInt 0))}}
+	{{validatorPackage .Type}}.ValueInt64sAre(int64validator.Between({{.MinInt}}, {{.MaxInt}})),
+	{{- else if and (isLeafList .Type) (eq (elementType .Type) "Float64") (or (ne .MinFloat 0.0) (ne .MaxFloat 0.0))}}
+	{{validatorPackage .Type}}.ValueFloat64sAre(float64validator.Between({{.MinFloat}}, {{.MaxFloat}})),
+	{{- end}}
+	{{- if len .ConflictsWith}}
+	{{validatorPackage .Type}}.ConflictsWith({{range .ConflictsWith}}path.MatchRelative().AtParent().AtName("{{.}}"), {{end}}),
+	{{- end}}
+	{{- if len .ExactlyOneOf}}
+	{{validatorPackage .Type}}.ExactlyOneOf({{range .ExactlyOneOf}}path.MatchRelative().AtParent().AtName("{{.}}"), {{end}}),
+	{{- end}}
+	{{- if len .AtLeastOneOf}}
+	{{validatorPackage .Type}}.AtLeastOneOf({{range .AtLeastOneOf}}path.MatchRelative().AtParent().AtName("{{.}}"), {{end}}),
+	{{- end}}
+	{{- if len .AlsoRequires}}
+	{{validatorPackage .Type}}.AlsoRequires({{range .AlsoRequires}}path.MatchRelative().AtParent().AtName("{{.}}"), {{end}}),
+	{{- end}}
+	{{- if len .WhenAttribute}}
+	helpers.AlsoRequiresValue(path.MatchRelative().AtParent().AtName("{{.WhenAttribute}}"), {{range .WhenValues}}"{{.}}", {{end}}),
+	{{- end}}
+},
+{{- end}}
+{{- end}}
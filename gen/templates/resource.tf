@@ -1,30 +1,40 @@
 resource "nso_{{snakeCase .Name}}" "example" {
 {{- range  .Attributes}}
 {{- if and (not .ExcludeTest) (not .ExcludeExample)}}
-{{- if eq .Type "List"}}
+{{- if isNestedList .Type}}
   {{.TfName}} = [
     {
       {{- range  .Attributes}}
       {{- if and (not .ExcludeTest) (not .ExcludeExample)}}
-      {{- if eq .Type "List"}}
+      {{- if isNestedList .Type}}
         {{.TfName}} = [
           {
             {{- range  .Attributes}}
             {{- if and (not .ExcludeTest) (not .ExcludeExample)}}
-            {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+            {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
             {{- end}}
             {{- end}}
           }
         ]
       {{- else}}
-      {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+      {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
       {{- end}}
       {{- end}}
       {{- end}}
     }
   ]
+{{- else if eq .Type "Map"}}
+  {{.TfName}} = {
+    "{{getKeyExample .Attributes}}" = {
+      {{- range  .Attributes}}
+      {{- if and (not .Id) (not (isNested .Type)) (not .ExcludeTest) (not .ExcludeExample)}}
+      {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+      {{- end}}
+      {{- end}}
+    }
+  }
 {{- else}}
-  {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+  {{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 {{- end}}
 {{- end}}
 {{- end}}
@@ -24,13 +24,16 @@ package provider
 import (
 	"testing"
 
+	{{- if hasId .Attributes}}
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	{{- end}}
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccNso{{camelCase .Name}}(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: {{if .TestPrerequisites}}testAccNso{{camelCase .Name}}PrerequisitesConfig+{{end}}testAccNso{{camelCase .Name}}Config_all(),
@@ -46,16 +49,56 @@ func TestAccNso{{camelCase .Name}}(t *testing.T) {
 					{{- $clist := .TfName }}
 					{{- range  .Attributes}}
 					{{- if and (not .WriteOnly) (not .ExcludeTest)}}
-					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{$clist}}.0.{{.TfName}}{{if or (eq .Type "StringList") (eq .Type "Int64List")}}.0{{end}}", "{{.Example}}"),
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{$clist}}.0.{{.TfName}}.*", "{{.Example}}"),
+					{{- else}}
+					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{$clist}}.0.{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
+					{{- end}}
+					{{- end}}
+					{{- end}}
+					{{- else if eq .Type "Set"}}
+					resource.TestCheckTypeSetElemNestedAttrs("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}.*", map[string]string{
+						{{- range  .Attributes}}
+						{{- if and (not .WriteOnly) (not .ExcludeTest) (not (isNested .Type)) (not (isLeafList .Type))}}
+						"{{.TfName}}": "{{.Example}}",
+						{{- end}}
+						{{- end}}
+					}),
+					{{- else}}
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}.*", "{{.Example}}"),
+					{{- else}}
+					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
 					{{- end}}
 					{{- end}}
+					{{- end}}
+					{{- end}}
+					{{- else if eq .Type "Set"}}
+					resource.TestCheckTypeSetElemNestedAttrs("nso_{{snakeCase $name}}.test", "{{.TfName}}.*", map[string]string{
+						{{- range  .Attributes}}
+						{{- if and (not .WriteOnly) (not .ExcludeTest) (not (isNested .Type)) (not (isLeafList .Type))}}
+						"{{.TfName}}": "{{.Example}}",
+						{{- end}}
+						{{- end}}
+					}),
+					{{- else if eq .Type "Map"}}
+					{{- $list := .TfName }}
+					{{- $key := getKeyExample .Attributes }}
+					{{- range  .Attributes}}
+					{{- if and (not .Id) (not .WriteOnly) (not .ExcludeTest)}}
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("nso_{{snakeCase $name}}.test", "{{$list}}.{{$key}}.{{.TfName}}.*", "{{.Example}}"),
 					{{- else}}
-					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{$list}}.0.{{.TfName}}{{if or (eq .Type "StringList") (eq .Type "Int64List")}}.0{{end}}", "{{.Example}}"),
+					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{$list}}.{{$key}}.{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
 					{{- end}}
 					{{- end}}
 					{{- end}}
 					{{- else}}
-					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{.TfName}}{{if or (eq .Type "StringList") (eq .Type "Int64List")}}.0{{end}}", "{{.Example}}"),
+					{{- if and (isLeafList .Type) (eq (collectionType .Type) "Set")}}
+					resource.TestCheckTypeSetElemAttr("nso_{{snakeCase $name}}.test", "{{.TfName}}.*", "{{.Example}}"),
+					{{- else}}
+					resource.TestCheckResourceAttr("nso_{{snakeCase $name}}.test", "{{.TfName}}{{if isLeafList .Type}}.0{{end}}", "{{.Example}}"),
+					{{- end}}
 					{{- end}}
 					{{- end}}
 					{{- end}}
@@ -70,6 +113,31 @@ func TestAccNso{{camelCase .Name}}(t *testing.T) {
 	})
 }
 
+{{- if hasId .Attributes}}
+
+func Test{{camelCase .Name}}ImportIdentity(t *testing.T) {
+	state := testImportResourceState(t, "nso_{{snakeCase .Name}}", map[string]tftypes.Value{
+		"instance": tftypes.NewValue(tftypes.String, "nso2"),
+		{{- range getKeyAttributes .Attributes}}
+		"{{.TfName}}": {{if eq .Type "Int64"}}tftypes.NewValue(tftypes.Number, {{.Example}}){{else}}tftypes.NewValue(tftypes.String, "{{.Example}}"){{end}},
+		{{- end}}
+	})
+	for name, want := range map[string]string{
+		"id":       "{{getExamplePath .Path .Attributes}}",
+		"instance": "nso2",
+		{{- range getKeyAttributes .Attributes}}
+		{{- if ne .Type "Int64"}}
+		"{{.TfName}}": "{{.Example}}",
+		{{- end}}
+		{{- end}}
+	} {
+		if got := testString(t, state[name]); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+{{- end}}
+
 {{- if .TestPrerequisites}}
 const testAccNso{{camelCase .Name}}PrerequisitesConfig = `
 {{- range $index, $item := .TestPrerequisites}}
@@ -115,26 +183,36 @@ func testAccNso{{camelCase .Name}}Config_minimum() string {
 	resource "nso_{{snakeCase $name}}" "test" {
 	{{- range  .Attributes}}
 	{{- if or .Reference .Id .Mandatory}}
-	{{- if eq .Type "List"}}
+	{{- if isNestedList .Type}}
 		{{.TfName}} = [{
 		{{- range  .Attributes}}
 		{{- if not .ExcludeTest}}
-		{{- if eq .Type "List"}}
+		{{- if isNestedList .Type}}
 			{{.TfName}} = [{
 				{{- range  .Attributes}}
 				{{- if not .ExcludeTest}}
-				{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+				{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 				{{- end}}
 				{{- end}}
 			}]
 		{{- else}}
-			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 		{{- end}}
 		{{- end}}
 		{{- end}}
 		}]
+	{{- else if eq .Type "Map"}}
+		{{.TfName}} = {
+			"{{getKeyExample .Attributes}}" = {
+				{{- range  .Attributes}}
+				{{- if and (not .Id) (not (isNested .Type)) (not .ExcludeTest)}}
+				{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+				{{- end}}
+				{{- end}}
+			}
+		}
 	{{- else}}
-		{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+		{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 	{{- end}}
 	{{- end}}
 	{{- end}}
@@ -153,26 +231,36 @@ func testAccNso{{camelCase .Name}}Config_all() string {
 	{{- end}}
 	{{- range  .Attributes}}
 	{{- if not .ExcludeTest}}
-	{{- if eq .Type "List"}}
+	{{- if isNestedList .Type}}
 		{{.TfName}} = [{
 		{{- range  .Attributes}}
 		{{- if not .ExcludeTest}}
-		{{- if eq .Type "List"}}
+		{{- if isNestedList .Type}}
 			{{.TfName}} = [{
 				{{- range  .Attributes}}
 				{{- if not .ExcludeTest}}
-				{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+				{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 				{{- end}}
 				{{- end}}
 			}]
 		{{- else}}
-			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+			{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 		{{- end}}
 		{{- end}}
 		{{- end}}
 		}]
+	{{- else if eq .Type "Map"}}
+		{{.TfName}} = {
+			"{{getKeyExample .Attributes}}" = {
+				{{- range  .Attributes}}
+				{{- if and (not .Id) (not (isNested .Type)) (not .ExcludeTest)}}
+				{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+				{{- end}}
+				{{- end}}
+			}
+		}
 	{{- else}}
-		{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if eq .Type "StringList"}}["{{.Example}}"]{{else if eq .Type "Int64List"}}[{{.Example}}]{{else}}{{.Example}}{{end}}
+		{{.TfName}} = {{if eq .Type "String"}}"{{.Example}}"{{else if and (isLeafList .Type) (eq (elementType .Type) "String")}}["{{.Example}}"]{{else if isLeafList .Type}}[{{.Example}}]{{else}}{{.Example}}{{end}}
 	{{- end}}
 	{{- end}}
 	{{- end}}
@@ -0,0 +1,265 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+// Package cassette provides an HTTP transport which records RESTCONF exchanges to cassette files and replays them
+// without an NSO instance. Credentials are redacted before an exchange is written, which allows committing cassettes
+// recorded against a lab NSO as regression tests.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+)
+
+type Mode string
+
+const (
+	// ModeRecord forwards requests to NSO and records the exchanges
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from a cassette, requests which have not been recorded fail
+	ModeReplay Mode = "replay"
+
+	// Redacted replaces credentials and secrets in recorded exchanges
+	Redacted = helpers.Redacted
+)
+
+// Headers which are recorded, all other headers like Authorization are dropped
+var recordedHeaders = []string{"Content-Type", "Accept"}
+
+// Cassette is the content of a cassette file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded HTTP exchange
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request, the host is not recorded so cassettes can be replayed against
+	// any URL
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// key identifies the requests which are answered by the same interactions
+func (r Request) key() string {
+	return r.Method + " " + r.URL + "\n" + r.Body
+}
+
+// Recorder records or replays the exchanges of all transports created by it using a single cassette file
+type Recorder struct {
+	Mode Mode
+	Path string
+	// Secrets are redacted wherever they occur in a recorded exchange, e.g. the NSO password
+	Secrets []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	// replay position per request key
+	played map[string]int
+}
+
+// New creates a recorder for the cassette file at path. In replay mode the cassette is loaded, a missing cassette
+// results in an error wrapping os.ErrNotExist.
+func New(mode Mode, path string, secrets ...string) (*Recorder, error) {
+	r := &Recorder{Mode: mode, Path: path, Secrets: secrets, played: map[string]int{}}
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cassette: %w", err)
+		}
+		if err := json.Unmarshal(content, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q", mode)
+	}
+	return r, nil
+}
+
+// Save writes the recorded exchanges to the cassette file. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.Path, append(content, '\n'), 0644)
+}
+
+// Interactions returns the exchanges recorded or loaded so far
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Transport returns a transport which records the exchanges of next, or replays them without calling next
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{recorder: r, next: next}
+}
+
+type transport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := t.recorder
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := Request{
+		Method:  req.Method,
+		URL:     r.redact(req.URL.RequestURI()),
+		Headers: headers(req.Header),
+		Body:    r.redactBody(body),
+	}
+
+	if r.Mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Headers:    headers(res.Header),
+			Body:       r.redactBody(resBody),
+		},
+	})
+	r.mu.Unlock()
+	return res, nil
+}
+
+// replay answers a request with the next unplayed interaction of an identical request. Identical requests are
+// answered in recorded order, the last interaction is repeated once all have been played. Requests are matched
+// independent of their order with other requests, as Terraform walks the resource graph concurrently.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := recorded.key()
+	var matches []Interaction
+	for _, i := range r.cassette.Interactions {
+		if i.Request.key() == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("cassette %s has no interaction for %s %s", r.Path, recorded.Method, recorded.URL)
+	}
+	n := r.played[key]
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	r.played[key]++
+	interaction := matches[n]
+
+	res := &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}
+	for k, v := range interaction.Response.Headers {
+		res.Header.Set(k, v)
+	}
+	return res, nil
+}
+
+func headers(h http.Header) map[string]string {
+	m := map[string]string{}
+	for _, name := range recordedHeaders {
+		if v := h.Get(name); v != "" {
+			m[name] = v
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return s
+}
+
+// redactBody redacts secrets and sensitive leaves, JSON bodies are normalized to match requests independent of the
+// member order
+func (r *Recorder) redactBody(body []byte) string {
+	return r.redact(helpers.RedactBody(body, helpers.SensitiveLeaves))
+}
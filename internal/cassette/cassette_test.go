@@ -0,0 +1,142 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package cassette
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/netascode/go-restconf"
+)
+
+func newTestClient(t *testing.T, url, password string, recorder *Recorder) *restconf.Client {
+	t.Helper()
+	client, err := restconf.NewClient(url, nsomock.DefaultUsername, password, true, restconf.MaxRetries(0), restconf.SkipDiscovery("/restconf", true))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	client.HttpClient.Transport = recorder.Transport(client.HttpClient.Transport)
+	return client
+}
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	s := nsomock.NewServer(nsomock.WithCredentials(nsomock.DefaultUsername, "nso-password"))
+	defer s.Close()
+
+	recorder, err := New(ModeRecord, path, s.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newTestClient(t, s.URL, s.Password, recorder)
+	if _, err := client.PutData("tailf-ncs:devices/authgroups/group=g1", `{"tailf-ncs:group":{"name":"g1","default-map":{"remote-name":"admin","remote-password":"lab-secret"}}}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetData("tailf-ncs:devices/authgroups/group=g1/default-map/remote-name"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteData("tailf-ncs:devices/authgroups/group=g1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetData("tailf-ncs:devices/authgroups/group=g1"); err == nil {
+		t.Fatal("expected error for deleted group")
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"lab-secret", "nso-password", "Authorization", "Basic "} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, content)
+		}
+	}
+
+	// The cassette is replayed without a server, members of request bodies may be in any order
+	replayer, err := New(ModeReplay, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = newTestClient(t, "https://nso.invalid", "other", replayer)
+	if _, err := client.PutData("tailf-ncs:devices/authgroups/group=g1", `{"tailf-ncs:group":{"default-map":{"remote-password":"lab-secret","remote-name":"admin"},"name":"g1"}}`); err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetData("tailf-ncs:devices/authgroups/group=g1/default-map/remote-name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.Res.Get("tailf-ncs:remote-name").String(); got != "admin" {
+		t.Errorf("replayed remote-name = %q, want %q", got, "admin")
+	}
+	if _, err := client.DeleteData("tailf-ncs:devices/authgroups/group=g1"); err != nil {
+		t.Fatal(err)
+	}
+	res, err = client.GetData("tailf-ncs:devices/authgroups/group=g1")
+	if err == nil || res.StatusCode != http.StatusNotFound {
+		t.Errorf("replayed GET of deleted group returned %d, %v", res.StatusCode, err)
+	}
+	if _, err := client.GetData("tailf-ncs:devices/device=ce0"); err == nil || !strings.Contains(err.Error(), "no interaction") {
+		t.Errorf("expected error for unrecorded request, got %v", err)
+	}
+}
+
+func TestReplayRepeatedRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+	s := nsomock.NewServer()
+	defer s.Close()
+
+	recorder, _ := New(ModeRecord, path)
+	client := newTestClient(t, s.URL, s.Password, recorder)
+	for _, hostname := range []string{"R1", "R2"} {
+		s.SetConfig("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":[{"name":"ce0","address":"`+hostname+`"}]}`)
+		client.GetData("tailf-ncs:devices/device=ce0")
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// identical requests are answered in recorded order, the last answer is repeated
+	replayer, _ := New(ModeReplay, path)
+	client = newTestClient(t, "https://nso.invalid", s.Password, replayer)
+	for _, want := range []string{"R1", "R2", "R2"} {
+		res, err := client.GetData("tailf-ncs:devices/device=ce0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Res.Get("tailf-ncs:device.0.address").String(); got != want {
+			t.Errorf("address = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(ModeReplay, filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+	if _, err := New("invalid", "test.json"); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
@@ -0,0 +1,665 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package nsomock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// The datastore is schema-less and keeps the data as decoded JSON: objects are map[string]interface{}, lists and
+// leaf-lists are []interface{} and leaves are json.Number, string, bool or nil. Member names are stored as they
+// appear in paths and bodies, which includes the module prefix whenever the module changes.
+type object = map[string]interface{}
+
+// restconfError is returned by datastore operations and rendered as RESTCONF error body
+type restconfError struct {
+	status  int
+	tag     string
+	message string
+}
+
+func (e *restconfError) Error() string {
+	return e.message
+}
+
+func errNotFound() *restconfError {
+	return &restconfError{http.StatusNotFound, "invalid-value", "uri keypath not found"}
+}
+
+func errPatchNonexistent() *restconfError {
+	return &restconfError{http.StatusNotFound, "invalid-value", "patch to a nonexistent resource"}
+}
+
+func errDataExists() *restconfError {
+	return &restconfError{http.StatusConflict, "data-exists", "object already exists"}
+}
+
+func errMalformed(format string, a ...interface{}) *restconfError {
+	return &restconfError{http.StatusBadRequest, "malformed-message", fmt.Sprintf(format, a...)}
+}
+
+type segment struct {
+	name string
+	// unescaped key values, nil if the segment has no keys
+	keys []string
+}
+
+// parsePath splits a RESTCONF data path like "tailf-ncs:devices/device=ce0/config" into segments. The path must be
+// escaped, so that keys containing "/" are kept intact.
+func parsePath(p string) ([]segment, *restconfError) {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil, nil
+	}
+	var segs []segment
+	for _, s := range strings.Split(p, "/") {
+		name, keyStr, hasKeys := strings.Cut(s, "=")
+		if name == "" {
+			return nil, errMalformed("invalid path: %s", p)
+		}
+		seg := segment{name: name}
+		if hasKeys {
+			for _, k := range strings.Split(keyStr, ",") {
+				v, err := url.PathUnescape(k)
+				if err != nil {
+					return nil, errMalformed("invalid key in path: %s", p)
+				}
+				seg.keys = append(seg.keys, v)
+			}
+		}
+		segs = append(segs, seg)
+	}
+	return segs, nil
+}
+
+func localName(name string) string {
+	if i := strings.Index(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// qualifiedName returns the name of the last segment prefixed with the module of the closest prefixed segment, which
+// is the name of the top-level member of request and response bodies
+func qualifiedName(segs []segment) string {
+	var prefix string
+	for _, seg := range segs {
+		if i := strings.Index(seg.name, ":"); i >= 0 {
+			prefix = seg.name[:i]
+		}
+	}
+	return prefix + ":" + localName(segs[len(segs)-1].name)
+}
+
+// findMember looks up a member by its name, names with and without module prefix are considered equal
+func findMember(obj object, name string) (string, bool) {
+	if _, ok := obj[name]; ok {
+		return name, true
+	}
+	for _, k := range sortedKeys(obj) {
+		if localName(k) == localName(name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+func sortedKeys(obj object) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func scalarString(v interface{}) (string, bool) {
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case json.Number:
+		return t.String(), true
+	case bool:
+		return fmt.Sprintf("%t", t), true
+	}
+	return "", false
+}
+
+// decodeJSON keeps numbers as json.Number to not lose precision of 64-bit integers
+func decodeJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch t := v.(type) {
+	case object:
+		c := make(object, len(t))
+		for k, e := range t {
+			c[k] = deepCopy(e)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(t))
+		for i, e := range t {
+			c[i] = deepCopy(e)
+		}
+		return c
+	}
+	return v
+}
+
+// datastore is a single tree of data, e.g. configuration or operational data
+type datastore struct {
+	root object
+	// key leaf names by list name without module prefix
+	listKeys map[string][]string
+}
+
+func newDatastore(listKeys map[string][]string) *datastore {
+	return &datastore{root: object{}, listKeys: listKeys}
+}
+
+// keyNames returns the key leaf names of a list entry. Lists which are not known are identified by a "name" or
+// "id" leaf, if present.
+func (d *datastore) keyNames(list string, entry object) []string {
+	if names, ok := d.listKeys[localName(list)]; ok {
+		return names
+	}
+	for _, name := range []string{"name", "id"} {
+		if _, ok := entry[name]; ok {
+			return []string{name}
+		}
+	}
+	return nil
+}
+
+// learnKeys records the key leaf names of a list from an entry addressed by its key values in a path
+func (d *datastore) learnKeys(list string, entry object, keys []string) {
+	if _, ok := d.listKeys[localName(list)]; ok {
+		return
+	}
+	if names := inferKeyNames(entry, keys); names != nil {
+		d.listKeys[localName(list)] = names
+	}
+}
+
+// inferKeyNames finds the members of an entry holding the given key values
+func inferKeyNames(entry object, keys []string) []string {
+	var names []string
+	used := map[string]bool{}
+	for _, key := range keys {
+		found := false
+		for _, k := range sortedKeys(entry) {
+			if s, ok := scalarString(entry[k]); ok && s == key && !used[k] {
+				names = append(names, k)
+				used[k] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return names
+}
+
+func entryMatches(entry object, names, keys []string) bool {
+	if len(names) != len(keys) {
+		return false
+	}
+	for i, name := range names {
+		s, ok := scalarString(entry[name])
+		if !ok || s != keys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// findEntry returns the index of the list entry with the given key values
+func (d *datastore) findEntry(list string, entries []interface{}, keys []string) int {
+	names, known := d.listKeys[localName(list)]
+	for i, e := range entries {
+		entry, ok := e.(object)
+		if !ok {
+			continue
+		}
+		if known && entryMatches(entry, names, keys) {
+			return i
+		}
+		if !known && inferKeyNames(entry, keys) != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// newEntry creates a list entry containing its key leaves
+func (d *datastore) newEntry(list string, keys []string) object {
+	names, ok := d.listKeys[localName(list)]
+	if !ok || len(names) != len(keys) {
+		names = []string{"name"}
+		for i := 1; i < len(keys); i++ {
+			names = append(names, fmt.Sprintf("key%d", i))
+		}
+	}
+	entry := object{}
+	for i, name := range names {
+		entry[name] = keys[i]
+	}
+	return entry
+}
+
+// walk returns the object containing the last segment. Missing nodes are created if create is set.
+func (d *datastore) walk(segs []segment, create bool) (object, *restconfError) {
+	current := d.root
+	for _, seg := range segs[:len(segs)-1] {
+		name, ok := findMember(current, seg.name)
+		if !ok {
+			if !create {
+				return nil, errNotFound()
+			}
+			name = seg.name
+			if seg.keys != nil {
+				current[name] = []interface{}{}
+			} else {
+				current[name] = object{}
+			}
+		}
+		if seg.keys != nil {
+			entries, ok := current[name].([]interface{})
+			if !ok {
+				return nil, errNotFound()
+			}
+			i := d.findEntry(name, entries, seg.keys)
+			if i < 0 {
+				if !create {
+					return nil, errNotFound()
+				}
+				entries = append(entries, d.newEntry(name, seg.keys))
+				current[name] = entries
+				i = len(entries) - 1
+			}
+			current = entries[i].(object)
+		} else {
+			child, ok := current[name].(object)
+			if !ok {
+				return nil, errNotFound()
+			}
+			current = child
+		}
+	}
+	return current, nil
+}
+
+// get returns the value of the node addressed by the path, list entries are returned as an array with a single
+// entry like in RESTCONF responses
+func (d *datastore) get(segs []segment) (interface{}, *restconfError) {
+	if len(segs) == 0 {
+		return d.root, nil
+	}
+	parent, err := d.walk(segs, false)
+	if err != nil {
+		return nil, err
+	}
+	last := segs[len(segs)-1]
+	name, ok := findMember(parent, last.name)
+	if !ok {
+		return nil, errNotFound()
+	}
+	if last.keys == nil {
+		return parent[name], nil
+	}
+	entries, ok := parent[name].([]interface{})
+	if !ok {
+		return nil, errNotFound()
+	}
+	i := d.findEntry(name, entries, last.keys)
+	if i < 0 {
+		return nil, errNotFound()
+	}
+	return []interface{}{entries[i]}, nil
+}
+
+func (d *datastore) exists(segs []segment) bool {
+	_, err := d.get(segs)
+	return err == nil
+}
+
+// bodyValue returns the value of the single top-level member of a request body, which must match the last segment
+func bodyValue(segs []segment, body interface{}) (interface{}, *restconfError) {
+	obj, ok := body.(object)
+	if !ok || len(obj) != 1 {
+		return nil, errMalformed("body must contain a single top-level member")
+	}
+	for k, v := range obj {
+		if len(segs) > 0 && localName(k) != localName(segs[len(segs)-1].name) {
+			return nil, errMalformed("body member %s does not match the target resource", k)
+		}
+		return v, nil
+	}
+	return nil, nil
+}
+
+// entryValue returns the list entry of a body addressing a single entry, which can be an object or an array with a
+// single object
+func entryValue(v interface{}) (object, *restconfError) {
+	if entries, ok := v.([]interface{}); ok {
+		if len(entries) != 1 {
+			return nil, errMalformed("body must contain a single list entry")
+		}
+		v = entries[0]
+	}
+	entry, ok := v.(object)
+	if !ok {
+		return nil, errMalformed("list entry must be an object")
+	}
+	return entry, nil
+}
+
+// merge merges the body into the existing node addressed by the path. If mustExist is set, a missing target results
+// in an error like a RESTCONF PATCH, otherwise the target is created.
+func (d *datastore) merge(segs []segment, body interface{}, mustExist bool) *restconfError {
+	if len(segs) == 0 {
+		obj, ok := body.(object)
+		if !ok {
+			return errMalformed("body must be an object")
+		}
+		d.mergeObject(d.root, obj)
+		return nil
+	}
+	value, err := bodyValue(segs, body)
+	if err != nil {
+		return err
+	}
+	parent, err := d.walk(segs, !mustExist)
+	if err != nil {
+		if mustExist {
+			return errPatchNonexistent()
+		}
+		return err
+	}
+	last := segs[len(segs)-1]
+	name, ok := findMember(parent, last.name)
+	if !ok {
+		if mustExist {
+			return errPatchNonexistent()
+		}
+		return d.replace(segs, body)
+	}
+	if last.keys != nil {
+		entry, err := entryValue(value)
+		if err != nil {
+			return err
+		}
+		entries, _ := parent[name].([]interface{})
+		i := d.findEntry(name, entries, last.keys)
+		if i < 0 {
+			if mustExist {
+				return errPatchNonexistent()
+			}
+			return d.replace(segs, body)
+		}
+		d.mergeObject(entries[i].(object), entry)
+		return nil
+	}
+	parent[name] = d.mergeValue(name, parent[name], value)
+	return nil
+}
+
+// replace replaces or creates the node addressed by the path
+func (d *datastore) replace(segs []segment, body interface{}) *restconfError {
+	if len(segs) == 0 {
+		obj, ok := body.(object)
+		if !ok {
+			return errMalformed("body must be an object")
+		}
+		d.root = deepCopy(obj).(object)
+		return nil
+	}
+	value, err := bodyValue(segs, body)
+	if err != nil {
+		return err
+	}
+	parent, err := d.walk(segs, true)
+	if err != nil {
+		return err
+	}
+	last := segs[len(segs)-1]
+	name, ok := findMember(parent, last.name)
+	if !ok {
+		name = last.name
+	}
+	if last.keys == nil {
+		parent[name] = deepCopy(value)
+		return nil
+	}
+	entry, err := entryValue(value)
+	if err != nil {
+		return err
+	}
+	entry = deepCopy(entry).(object)
+	d.learnKeys(name, entry, last.keys)
+	for i, n := range d.keyNames(name, entry) {
+		if _, ok := entry[n]; !ok && i < len(last.keys) {
+			entry[n] = last.keys[i]
+		}
+	}
+	entries, _ := parent[name].([]interface{})
+	if i := d.findEntry(name, entries, last.keys); i >= 0 {
+		entries[i] = entry
+	} else {
+		entries = append(entries, entry)
+	}
+	parent[name] = entries
+	return nil
+}
+
+// create adds the single member of the body as child of the node addressed by the path like a RESTCONF POST
+func (d *datastore) create(segs []segment, body interface{}) *restconfError {
+	obj, ok := body.(object)
+	if !ok || len(obj) != 1 {
+		return errMalformed("body must contain a single top-level member")
+	}
+	target := d.root
+	if len(segs) > 0 {
+		// containers are created implicitly, as there is no schema to tell them from presence containers
+		if last := segs[len(segs)-1]; last.keys == nil && !d.exists(segs) {
+			if err := d.replace(segs, object{last.name: object{}}); err != nil {
+				return err
+			}
+		}
+		v, err := d.get(segs)
+		if err != nil {
+			return err
+		}
+		if entries, ok := v.([]interface{}); ok && len(entries) == 1 {
+			v = entries[0]
+		}
+		if target, ok = v.(object); !ok {
+			return errMalformed("target resource is not a container or list entry")
+		}
+	}
+	for k, v := range obj {
+		childName := k
+		if len(segs) > 0 {
+			childName = d.childName(segs, k)
+		}
+		name, exists := findMember(target, childName)
+		if !exists {
+			target[childName] = deepCopy(v)
+			return nil
+		}
+		entries, isList := target[name].([]interface{})
+		if !isList {
+			return errDataExists()
+		}
+		newEntries, ok := v.([]interface{})
+		if !ok {
+			newEntries = []interface{}{v}
+		}
+		for _, e := range newEntries {
+			entry, ok := e.(object)
+			if !ok {
+				return errMalformed("list entry must be an object")
+			}
+			names := d.keyNames(name, entry)
+			if names != nil && d.findEntryByEntry(entries, names, entry) >= 0 {
+				return errDataExists()
+			}
+			entries = append(entries, deepCopy(entry))
+		}
+		target[name] = entries
+	}
+	return nil
+}
+
+// childName strips the module prefix of a body member if the parent belongs to the same module
+func (d *datastore) childName(segs []segment, name string) string {
+	i := strings.Index(name, ":")
+	if i < 0 {
+		return name
+	}
+	parent := qualifiedName(segs)
+	if parent[:strings.Index(parent, ":")] == name[:i] {
+		return name[i+1:]
+	}
+	return name
+}
+
+// delete removes the node addressed by the path
+func (d *datastore) delete(segs []segment) *restconfError {
+	if len(segs) == 0 {
+		d.root = object{}
+		return nil
+	}
+	parent, err := d.walk(segs, false)
+	if err != nil {
+		return err
+	}
+	last := segs[len(segs)-1]
+	name, ok := findMember(parent, last.name)
+	if !ok {
+		return errNotFound()
+	}
+	if last.keys == nil {
+		delete(parent, name)
+		return nil
+	}
+	entries, _ := parent[name].([]interface{})
+	i := d.findEntry(name, entries, last.keys)
+	if i < 0 {
+		return errNotFound()
+	}
+	entries = append(entries[:i], entries[i+1:]...)
+	if len(entries) == 0 {
+		delete(parent, name)
+	} else {
+		parent[name] = entries
+	}
+	return nil
+}
+
+func (d *datastore) mergeObject(dst, src object) {
+	for k, v := range src {
+		name, ok := findMember(dst, k)
+		if !ok {
+			dst[k] = deepCopy(v)
+			continue
+		}
+		dst[name] = d.mergeValue(name, dst[name], v)
+	}
+}
+
+// mergeValue merges objects recursively, list entries by their keys and leaf-list values by their value
+func (d *datastore) mergeValue(name string, dst, src interface{}) interface{} {
+	switch s := src.(type) {
+	case object:
+		if dstObj, ok := dst.(object); ok {
+			d.mergeObject(dstObj, s)
+			return dstObj
+		}
+		if dstList, ok := dst.([]interface{}); ok {
+			return d.mergeList(name, dstList, []interface{}{s})
+		}
+	case []interface{}:
+		if dstList, ok := dst.([]interface{}); ok {
+			return d.mergeList(name, dstList, s)
+		}
+	}
+	return deepCopy(src)
+}
+
+func (d *datastore) mergeList(name string, dst, src []interface{}) []interface{} {
+	for _, e := range src {
+		entry, ok := e.(object)
+		if !ok {
+			// leaf-list value
+			found := false
+			for _, existing := range dst {
+				if a, ok := scalarString(existing); ok {
+					if b, ok := scalarString(e); ok && a == b {
+						found = true
+						break
+					}
+				}
+			}
+			if !found {
+				dst = append(dst, e)
+			}
+			continue
+		}
+		names := d.keyNames(name, entry)
+		if i := d.findEntryByEntry(dst, names, entry); names != nil && i >= 0 {
+			d.mergeObject(dst[i].(object), entry)
+		} else {
+			dst = append(dst, deepCopy(entry))
+		}
+	}
+	return dst
+}
+
+func (d *datastore) findEntryByEntry(entries []interface{}, names []string, entry object) int {
+	var keys []string
+	for _, name := range names {
+		s, ok := scalarString(entry[name])
+		if !ok {
+			return -1
+		}
+		keys = append(keys, s)
+	}
+	for i, e := range entries {
+		if existing, ok := e.(object); ok && entryMatches(existing, names, keys) {
+			return i
+		}
+	}
+	return -1
+}
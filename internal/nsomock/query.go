@@ -0,0 +1,156 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package nsomock
+
+import (
+	"strconv"
+	"strings"
+)
+
+// limitDepth implements the RESTCONF "depth" query parameter, the target node has depth 1 and list entries have the
+// same depth as their list
+func limitDepth(v interface{}, depth int) interface{} {
+	switch t := v.(type) {
+	case object:
+		c := object{}
+		if depth <= 1 {
+			return c
+		}
+		for k, e := range t {
+			c[k] = limitDepth(e, depth-1)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(t))
+		for i, e := range t {
+			c[i] = limitDepth(e, depth)
+		}
+		return c
+	}
+	return v
+}
+
+func parseDepth(s string) (int, *restconfError) {
+	if s == "" || s == "unbounded" {
+		return 0, nil
+	}
+	depth, err := strconv.Atoi(s)
+	if err != nil || depth < 1 || depth > 65535 {
+		return 0, &restconfError{400, "invalid-value", "invalid value for depth: " + s}
+	}
+	return depth, nil
+}
+
+// fieldsNode is a node of a parsed "fields" expression, like "name;config(hostname;interface/name)"
+type fieldsNode struct {
+	// the whole subtree is selected
+	all      bool
+	children map[string]*fieldsNode
+}
+
+func parseFields(s string) (map[string]*fieldsNode, *restconfError) {
+	tree := map[string]*fieldsNode{}
+	for _, field := range splitTopLevel(s) {
+		path, sub := field, ""
+		if i := strings.Index(field, "("); i >= 0 {
+			if !strings.HasSuffix(field, ")") {
+				return nil, &restconfError{400, "invalid-value", "invalid value for fields: " + s}
+			}
+			path, sub = field[:i], field[i+1:len(field)-1]
+		}
+		if path == "" {
+			return nil, &restconfError{400, "invalid-value", "invalid value for fields: " + s}
+		}
+		nodes := tree
+		names := strings.Split(path, "/")
+		for i, name := range names {
+			node, ok := nodes[name]
+			if !ok {
+				node = &fieldsNode{}
+				nodes[name] = node
+			}
+			if node.children == nil {
+				node.children = map[string]*fieldsNode{}
+			}
+			if i < len(names)-1 {
+				nodes = node.children
+				continue
+			}
+			if sub == "" {
+				node.all = true
+				continue
+			}
+			subtree, err := parseFields(sub)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range subtree {
+				node.children[k] = v
+			}
+		}
+	}
+	return tree, nil
+}
+
+// splitTopLevel splits a fields expression at semicolons which are not enclosed in parentheses
+func splitTopLevel(s string) []string {
+	var parts []string
+	level, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			level++
+		case ')':
+			level--
+		case ';':
+			if level == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// selectFields implements the RESTCONF "fields" query parameter on the value of the target node
+func selectFields(v interface{}, tree map[string]*fieldsNode) interface{} {
+	switch t := v.(type) {
+	case object:
+		c := object{}
+		for k, e := range t {
+			for name, node := range tree {
+				if localName(name) != localName(k) {
+					continue
+				}
+				if node.all {
+					c[k] = e
+				} else {
+					c[k] = selectFields(e, node.children)
+				}
+			}
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(t))
+		for i, e := range t {
+			c[i] = selectFields(e, tree)
+		}
+		return c
+	}
+	return v
+}
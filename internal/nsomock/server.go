@@ -0,0 +1,462 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+// Package nsomock provides an in-process stand-in for the NSO RESTCONF API, which allows running the acceptance tests
+// without an NSO instance. It keeps configuration and operational data in schema-less in-memory JSON datastores.
+package nsomock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	RootEndpoint = "/restconf"
+	DataEndpoint = "/restconf/data"
+	// Header carrying the token of a session, if token responses are enabled by WithAuthToken
+	AuthTokenHeader = "X-Auth-Token"
+	// Default credentials accepted by the server
+	DefaultUsername = "admin"
+	DefaultPassword = "admin"
+)
+
+// ActionFunc handles the invocation of a YANG action or RPC. It receives the path of the action and the input and
+// returns the output, nil results in a response without body.
+type ActionFunc func(path string, input map[string]interface{}) map[string]interface{}
+
+// DefaultActions are the actions the server responds to, they report every device and service to be in sync
+var DefaultActions = map[string]ActionFunc{
+	"check-sync": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"result": "in-sync", "in-sync": true}
+	},
+	"sync-from": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"result": true}
+	},
+	"sync-to": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"result": true}
+	},
+	"get-modifications": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{}
+	},
+	"re-deploy": func(string, map[string]interface{}) map[string]interface{} {
+		return nil
+	},
+	"un-deploy": func(string, map[string]interface{}) map[string]interface{} {
+		return nil
+	},
+	"connect": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"result": true, "info": "(admin) Connected to device"}
+	},
+	// Devices have no differences
+	"compare-config": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{}
+	},
+	"reload": func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"reload-result": []interface{}{}}
+	},
+}
+
+// DefaultListKeys are the key leaves of NSO lists used by the provider, keys of other lists are learned when
+// entries are created using a path with keys
+var DefaultListKeys = map[string][]string{
+	"device":       {"name"},
+	"device-group": {"name"},
+	"group":        {"name"},
+	"umap":         {"local-user"},
+	"customer":     {"id"},
+	"queue-item":   {"id"},
+	"file":         {"id"},
+	"secondary":    {"address"},
+}
+
+// Server is an in-process NSO RESTCONF server
+type Server struct {
+	*httptest.Server
+	Username string
+	Password string
+	// Token returned to authenticated requests and accepted instead of the credentials, empty if disabled
+	Token string
+
+	mu          sync.Mutex
+	listKeys    map[string][]string
+	config      *datastore
+	operational *datastore
+	actions     map[string]ActionFunc
+}
+
+type Option func(*Server)
+
+// WithCredentials sets the credentials accepted by the server
+func WithCredentials(username, password string) Option {
+	return func(s *Server) {
+		s.Username = username
+		s.Password = password
+	}
+}
+
+// WithAuthToken enables token responses like the x-auth-token setting of ncs.conf does. The token is returned in the
+// X-Auth-Token header of authenticated requests and accepted instead of the credentials.
+func WithAuthToken(token string) Option {
+	return func(s *Server) {
+		s.Token = token
+	}
+}
+
+// WithListKeys defines the key leaves of a list, identified by its name without module prefix
+func WithListKeys(list string, keys ...string) Option {
+	return func(s *Server) {
+		s.listKeys[list] = keys
+	}
+}
+
+// WithAction adds or replaces the handler of an action, identified by its name without module prefix
+func WithAction(name string, action ActionFunc) Option {
+	return func(s *Server) {
+		s.actions[name] = action
+	}
+}
+
+// NewServer starts a new server, which must be closed by the caller
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		listKeys: map[string][]string{},
+		actions:  map[string]ActionFunc{},
+	}
+	for k, v := range DefaultListKeys {
+		s.listKeys[k] = v
+	}
+	for k, v := range DefaultActions {
+		s.actions[k] = v
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.config = newDatastore(s.listKeys)
+	s.operational = newDatastore(s.listKeys)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetConfig merges configuration data into the datastore, the body is a RESTCONF JSON body for the path
+func (s *Server) SetConfig(path, body string) error {
+	return s.set(s.config, path, body)
+}
+
+// SetOperational merges operational data into the datastore, which is returned for "content=nonconfig"
+func (s *Server) SetOperational(path, body string) error {
+	return s.set(s.operational, path, body)
+}
+
+func (s *Server) set(d *datastore, path, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	v, e := decodeJSON([]byte(body))
+	if e != nil {
+		return e
+	}
+	if err := d.merge(segs, v, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Reset removes all configuration and operational data
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = newDatastore(s.listKeys)
+	s.operational = newDatastore(s.listKeys)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/yang-data+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *restconfError) {
+	writeJSON(w, err.status, errorsBody(err))
+}
+
+func errorsBody(err *restconfError) object {
+	return object{"ietf-restconf:errors": object{"error": []interface{}{object{
+		"error-type":    "application",
+		"error-tag":     err.tag,
+		"error-message": err.message,
+	}}}}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticated(r) {
+		writeError(w, &restconfError{http.StatusUnauthorized, "access-denied", "access denied"})
+		return
+	}
+	if s.Token != "" {
+		w.Header().Set(AuthTokenHeader, s.Token)
+	}
+	// keys are escaped, e.g. "interface=0%2F1", and must be split before unescaping
+	path := r.URL.EscapedPath()
+	if path == RootEndpoint && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, object{"ietf-restconf:restconf": object{"data": object{}, "operations": object{}, "yang-library-version": "2019-01-04"}})
+		return
+	}
+	if path != DataEndpoint && !strings.HasPrefix(path, DataEndpoint+"/") {
+		writeError(w, errNotFound())
+		return
+	}
+	segs, err := parsePath(strings.TrimPrefix(path, DataEndpoint))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var body interface{}
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			v, e := decodeJSON(data)
+			if e != nil {
+				writeError(w, errMalformed("invalid JSON body: %s", e))
+				return
+			}
+			body = v
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGet(w, r, segs)
+	case http.MethodPatch:
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/yang-patch+json") {
+			s.handleYangPatch(w, segs, body)
+			return
+		}
+		if err := s.config.merge(segs, body, true); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		existed := s.config.exists(segs)
+		if err := s.config.replace(segs, body); err != nil {
+			writeError(w, err)
+			return
+		}
+		if existed {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+	case http.MethodPost:
+		if len(segs) > 0 {
+			if action, ok := s.actions[localName(segs[len(segs)-1].name)]; ok {
+				s.handleAction(w, segs, body, action)
+				return
+			}
+		}
+		if err := s.config.create(segs, body); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		if err := s.config.delete(segs); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, &restconfError{http.StatusMethodNotAllowed, "operation-not-supported", "method not supported"})
+	}
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	if s.Token != "" && r.Header.Get(AuthTokenHeader) == s.Token {
+		return true
+	}
+	u, p, ok := r.BasicAuth()
+	return ok && u == s.Username && p == s.Password
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request, segs []segment) {
+	query := r.URL.Query()
+	var stores []*datastore
+	switch query.Get("content") {
+	case "config":
+		stores = []*datastore{s.config}
+	case "nonconfig":
+		stores = []*datastore{s.operational}
+	case "", "all":
+		stores = []*datastore{s.config, s.operational}
+	default:
+		writeError(w, &restconfError{http.StatusBadRequest, "invalid-value", "invalid value for content: " + query.Get("content")})
+		return
+	}
+
+	// configuration and operational data are merged like NSO does for "content=all"
+	var value interface{}
+	found := false
+	merged := newDatastore(s.listKeys)
+	for _, d := range stores {
+		v, err := d.get(segs)
+		if err != nil {
+			continue
+		}
+		if !found {
+			value = deepCopy(v)
+			found = true
+		} else {
+			value = merged.mergeValue(lastName(segs), value, v)
+		}
+	}
+	if !found {
+		writeError(w, errNotFound())
+		return
+	}
+
+	if fields := query.Get("fields"); fields != "" {
+		tree, err := parseFields(fields)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		value = selectFields(value, tree)
+	}
+	depth, err := parseDepth(query.Get("depth"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if depth > 0 {
+		value = limitDepth(value, depth)
+	}
+
+	if len(segs) == 0 {
+		writeJSON(w, http.StatusOK, object{"ietf-restconf:data": value})
+		return
+	}
+	writeJSON(w, http.StatusOK, object{qualifiedName(segs): value})
+}
+
+func lastName(segs []segment) string {
+	if len(segs) == 0 {
+		return ""
+	}
+	return segs[len(segs)-1].name
+}
+
+// handleAction answers actions for any path, the node the action belongs to does not need to exist
+func (s *Server) handleAction(w http.ResponseWriter, segs []segment, body interface{}, action ActionFunc) {
+	input := map[string]interface{}{}
+	if obj, ok := body.(object); ok {
+		for k, v := range obj {
+			if localName(k) == "input" {
+				if in, ok := v.(object); ok {
+					input = in
+				}
+			}
+		}
+	}
+	var p []string
+	for _, seg := range segs {
+		p = append(p, seg.name)
+	}
+	output := action(strings.Join(p, "/"), input)
+	if output == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	name := qualifiedName(segs)
+	writeJSON(w, http.StatusOK, object{name[:strings.Index(name, ":")] + ":output": output})
+}
+
+// handleYangPatch applies all edits of a YANG-Patch (RFC 8072) request or none of them
+func (s *Server) handleYangPatch(w http.ResponseWriter, segs []segment, body interface{}) {
+	patch, _ := body.(object)["ietf-yang-patch:yang-patch"].(object)
+	if patch == nil {
+		writeError(w, errMalformed("missing ietf-yang-patch:yang-patch"))
+		return
+	}
+	patchId, _ := patch["patch-id"].(string)
+	edits, _ := patch["edit"].([]interface{})
+
+	// edits are applied to a copy, which replaces the datastore if all edits succeed
+	candidate := newDatastore(s.listKeys)
+	candidate.root = deepCopy(s.config.root).(object)
+	for _, e := range edits {
+		edit, _ := e.(object)
+		editId, _ := edit["edit-id"].(string)
+		target, _ := edit["target"].(string)
+		operation, _ := edit["operation"].(string)
+
+		editSegs, err := parsePath(strings.TrimPrefix(target, "/"))
+		if err == nil {
+			editSegs = append(append([]segment{}, segs...), editSegs...)
+			err = applyEdit(candidate, editSegs, operation, edit["value"])
+		}
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, object{"ietf-yang-patch:yang-patch-status": object{
+				"patch-id": patchId,
+				"edit-status": object{"edit": []interface{}{object{
+					"edit-id": editId,
+					"errors":  errorsBody(err)["ietf-restconf:errors"],
+				}}},
+			}})
+			return
+		}
+	}
+	s.config = candidate
+	writeJSON(w, http.StatusOK, object{"ietf-yang-patch:yang-patch-status": object{
+		"patch-id": patchId,
+		"ok":       []interface{}{nil},
+	}})
+}
+
+func applyEdit(d *datastore, segs []segment, operation string, value interface{}) *restconfError {
+	switch operation {
+	case "create":
+		if d.exists(segs) {
+			return errDataExists()
+		}
+		return d.replace(segs, value)
+	case "merge":
+		return d.merge(segs, value, false)
+	case "replace":
+		return d.replace(segs, value)
+	case "delete":
+		return d.delete(segs)
+	case "remove":
+		if err := d.delete(segs); err != nil && err.status != http.StatusNotFound {
+			return err
+		}
+		return nil
+	}
+	return &restconfError{http.StatusBadRequest, "operation-not-supported", "unsupported edit operation: " + operation}
+}
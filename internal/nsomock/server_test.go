@@ -0,0 +1,246 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package nsomock
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/netascode/go-restconf"
+)
+
+func newTestClient(t *testing.T, s *Server) *restconf.Client {
+	t.Helper()
+	client, err := restconf.NewClient(s.URL, s.Username, s.Password, true, restconf.MaxRetries(0), restconf.SkipDiscovery("/restconf", true))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	return client
+}
+
+func TestServerAuthentication(t *testing.T) {
+	s := NewServer(WithCredentials("user", "secret"))
+	defer s.Close()
+	client, _ := restconf.NewClient(s.URL, "user", "wrong", true, restconf.MaxRetries(0), restconf.SkipDiscovery("/restconf", true))
+
+	res, err := client.GetData("tailf-ncs:devices")
+	if err == nil || res.StatusCode != 401 {
+		t.Fatalf("expected status 401, got %d", res.StatusCode)
+	}
+}
+
+func TestServerAuthToken(t *testing.T) {
+	s := NewServer(WithAuthToken("token1"))
+	defer s.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, s.URL+RootEndpoint, nil)
+	req.SetBasicAuth(s.Username, s.Password)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != 200 || res.Header.Get(AuthTokenHeader) != "token1" {
+		t.Fatalf("expected status 200 with token, got %d and %q", res.StatusCode, res.Header.Get(AuthTokenHeader))
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, s.URL+DataEndpoint+"/tailf-ncs:devices", nil)
+	req.Header.Set(AuthTokenHeader, "token1")
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode == 401 {
+		t.Fatal("expected token to be accepted instead of credentials")
+	}
+}
+
+func TestServerCrud(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	res, err := client.PatchData("tailf-ncs:customers/customer", `{"tailf-ncs:customer":{"id":"123","rank":10}}`)
+	if err == nil || res.StatusCode != 404 {
+		t.Fatalf("expected status 404, got %d", res.StatusCode)
+	}
+	if msg := res.Errors.Error[0].ErrorMessage; msg != "patch to a nonexistent resource" {
+		t.Errorf("unexpected error message: %s", msg)
+	}
+
+	res, err = client.PutData("tailf-ncs:customers/customer=123", `{"tailf-ncs:customer":{"id":"123","rank":10}}`)
+	if err != nil || res.StatusCode != 201 {
+		t.Fatalf("expected status 201, got %d: %v", res.StatusCode, err)
+	}
+	res, err = client.PatchData("tailf-ncs:customers/customer", `{"tailf-ncs:customer":{"id":"123","status":"active"}}`)
+	if err != nil || res.StatusCode != 204 {
+		t.Fatalf("expected status 204, got %d: %v", res.StatusCode, err)
+	}
+
+	res, err = client.GetData("tailf-ncs:customers/customer=123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := res.Res.Get(`tailf-ncs:customer.0.rank`).Int(); v != 10 {
+		t.Errorf("expected rank 10, got %d", v)
+	}
+	if v := res.Res.Get(`tailf-ncs:customer.0.status`).String(); v != "active" {
+		t.Errorf("expected status active, got %s", v)
+	}
+
+	res, err = client.PutData("tailf-ncs:customers/customer=123", `{"tailf-ncs:customer":{"id":"123"}}`)
+	if err != nil || res.StatusCode != 204 {
+		t.Fatalf("expected status 204, got %d: %v", res.StatusCode, err)
+	}
+	res, _ = client.GetData("tailf-ncs:customers/customer=123")
+	if res.Res.Get(`tailf-ncs:customer.0.rank`).Exists() {
+		t.Errorf("expected rank to be removed by replace")
+	}
+
+	res, err = client.DeleteData("tailf-ncs:customers/customer=123")
+	if err != nil || res.StatusCode != 204 {
+		t.Fatalf("expected status 204, got %d: %v", res.StatusCode, err)
+	}
+	res, err = client.GetData("tailf-ncs:customers/customer=123")
+	if err == nil || res.StatusCode != 404 {
+		t.Fatalf("expected status 404, got %d", res.StatusCode)
+	}
+	res, err = client.DeleteData("tailf-ncs:customers/customer=123")
+	if err == nil || res.StatusCode != 404 {
+		t.Fatalf("expected status 404, got %d", res.StatusCode)
+	}
+}
+
+func TestServerEscapedKeys(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	_, err := client.PutData("tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", `{"tailf-ned-cisco-ios:GigabitEthernet":{"name":"0/1","description":"uplink"}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := client.GetData("tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := res.Res.Get(`tailf-ned-cisco-ios:GigabitEthernet.0.description`).String(); v != "uplink" {
+		t.Errorf("expected description uplink, got %s", v)
+	}
+}
+
+func TestServerQueryParameters(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	if err := s.SetConfig("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":{"name":"ce0","address":"10.0.0.1","state":{"admin-state":"unlocked"}}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.SetOperational("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":{"name":"ce0","platform":{"name":"ios"}}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, _ := client.GetData("tailf-ncs:devices/device=ce0", restconf.Query("content", "config"))
+	if res.Res.Get(`tailf-ncs:device.0.platform`).Exists() {
+		t.Errorf("expected no operational data with content=config")
+	}
+	res, _ = client.GetData("tailf-ncs:devices/device=ce0", restconf.Query("content", "nonconfig"))
+	if res.Res.Get(`tailf-ncs:device.0.address`).Exists() || !res.Res.Get(`tailf-ncs:device.0.platform`).Exists() {
+		t.Errorf("expected only operational data with content=nonconfig, got %s", res.Res.Raw)
+	}
+	res, _ = client.GetData("tailf-ncs:devices/device=ce0")
+	if !res.Res.Get(`tailf-ncs:device.0.address`).Exists() || !res.Res.Get(`tailf-ncs:device.0.platform`).Exists() {
+		t.Errorf("expected all data without content parameter, got %s", res.Res.Raw)
+	}
+
+	res, _ = client.GetData("tailf-ncs:devices/device=ce0", restconf.Query("content", "config"), restconf.Query("depth", "2"))
+	if !res.Res.Get(`tailf-ncs:device.0.address`).Exists() || res.Res.Get(`tailf-ncs:device.0.state.admin-state`).Exists() {
+		t.Errorf("unexpected result with depth=2: %s", res.Res.Raw)
+	}
+
+	res, _ = client.GetData("tailf-ncs:devices/device=ce0", restconf.Query("fields", "name;state(admin-state)"))
+	if res.Res.Get(`tailf-ncs:device.0.address`).Exists() || res.Res.Get(`tailf-ncs:device.0.state.admin-state`).String() != "unlocked" {
+		t.Errorf("unexpected result with fields: %s", res.Res.Raw)
+	}
+
+	res, err := client.GetData("tailf-ncs:devices/device=ce0", restconf.Query("depth", "0"))
+	if err == nil || res.StatusCode != 400 {
+		t.Errorf("expected status 400, got %d", res.StatusCode)
+	}
+}
+
+func TestServerYangPatch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	edits := []restconf.YangPatchEdit{
+		restconf.NewYangPatchEdit("merge", "tailf-ncs:customers/customer=123", restconf.Body{Str: `{"tailf-ncs:customer":{"id":"123","rank":10}}`}),
+		restconf.NewYangPatchEdit("remove", "tailf-ncs:customers/customer=456", restconf.Body{}),
+	}
+	res, err := client.YangPatchData("", "1", "", edits)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Res.Get(`ietf-yang-patch:yang-patch-status.ok`).Exists() {
+		t.Errorf("expected ok status, got %s", res.Res.Raw)
+	}
+
+	// the failing delete must roll back the merge
+	edits = []restconf.YangPatchEdit{
+		restconf.NewYangPatchEdit("merge", "tailf-ncs:customers/customer=789", restconf.Body{Str: `{"tailf-ncs:customer":{"id":"789"}}`}),
+		restconf.NewYangPatchEdit("delete", "tailf-ncs:customers/customer=456", restconf.Body{}),
+	}
+	res, err = client.YangPatchData("", "2", "", edits)
+	if err == nil || res.StatusCode != 400 {
+		t.Fatalf("expected status 400, got %d", res.StatusCode)
+	}
+	if res, _ := client.GetData("tailf-ncs:customers/customer=789"); res.StatusCode != 404 {
+		t.Errorf("expected status 404, got %d", res.StatusCode)
+	}
+	if res, _ := client.GetData("tailf-ncs:customers/customer=123"); res.StatusCode != 200 {
+		t.Errorf("expected status 200, got %d", res.StatusCode)
+	}
+}
+
+func TestServerActions(t *testing.T) {
+	s := NewServer(WithAction("check-sync", func(path string, input map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"result": "out-of-sync"}
+	}))
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	res, err := client.PostData("tailf-ncs:devices/device=ce0/check-sync", `{"input":{}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := res.Res.Get(`tailf-ncs:output.result`).String(); v != "out-of-sync" {
+		t.Errorf("expected result out-of-sync, got %s", v)
+	}
+
+	res, err = client.PostData("tailf-ncs:customers", `{"tailf-ncs:customer":{"id":"123"}}`)
+	if err != nil || res.StatusCode != 201 {
+		t.Fatalf("expected status 201, got %d: %v", res.StatusCode, err)
+	}
+	res, err = client.PostData("tailf-ncs:customers", `{"tailf-ncs:customer":{"id":"123"}}`)
+	if err == nil || res.StatusCode != 409 {
+		t.Fatalf("expected status 409, got %d", res.StatusCode)
+	}
+}
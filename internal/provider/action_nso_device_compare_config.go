@@ -0,0 +1,121 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ action.ActionWithConfigure = &DeviceCompareConfigAction{}
+
+func NewDeviceCompareConfigAction() action.Action {
+	return &DeviceCompareConfigAction{}
+}
+
+type DeviceCompareConfigAction struct {
+	clients map[string]*restconf.Client
+}
+
+func (a *DeviceCompareConfigAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_compare_config"
+}
+
+func (a *DeviceCompareConfigAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Compares the configuration of a device in NSO with the configuration on the device using the `compare-config` action and reports the differences.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "The name of the device.",
+				Required:            true,
+			},
+			"outformat": schema.StringAttribute{
+				MarkdownDescription: helpers.NewAttributeDescription("Format of the differences, by default the NSO setting is used.").AddStringEnumDescription("cli", "xml", "native").String,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("cli", "xml", "native"),
+				},
+			},
+			"fail_on_diff": schema.BoolAttribute{
+				MarkdownDescription: "Report differences as error instead of a progress message.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *DeviceCompareConfigAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (a *DeviceCompareConfigAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config DeviceCompareConfig
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := a.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	actionPath := deviceActionPath(config.Device, "compare-config")
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Invoke", actionPath))
+
+	res, err := client.PostData(actionPath, config.toBody())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to invoke compare-config action, got error: %s", err))
+		return
+	}
+	diff := compareConfigDiff(res.Res)
+	if diff == "" {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Device %s is in sync", config.Device.ValueString())})
+	} else if config.FailOnDiff.ValueBool() {
+		resp.Diagnostics.AddError("Device out of sync", fmt.Sprintf("The configuration of device %s differs from NSO:\n%s", config.Device.ValueString(), diff))
+		return
+	} else {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("The configuration of device %s differs from NSO:\n%s", config.Device.ValueString(), diff)})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Invoke finished successfully", actionPath))
+}
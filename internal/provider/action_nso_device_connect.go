@@ -0,0 +1,104 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ action.ActionWithConfigure = &DeviceConnectAction{}
+
+func NewDeviceConnectAction() action.Action {
+	return &DeviceConnectAction{}
+}
+
+type DeviceConnectAction struct {
+	clients map[string]*restconf.Client
+}
+
+func (a *DeviceConnectAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_connect"
+}
+
+func (a *DeviceConnectAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Connects NSO to a device using the `connect` action, e.g. to verify the credentials and reachability of a new device.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "The name of the device.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *DeviceConnectAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (a *DeviceConnectAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config DeviceConnect
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := a.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	actionPath := deviceActionPath(config.Device, "connect")
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Invoke", actionPath))
+
+	res, err := client.PostData(actionPath, `{"input":{}}`)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to invoke connect action, got error: %s", err))
+		return
+	}
+	result, info := deviceActionResult(res.Res)
+	if !result {
+		resp.Diagnostics.AddError("Action failed", fmt.Sprintf("Failed to connect to device %s: %s", config.Device.ValueString(), info))
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Connected to device %s: %s", config.Device.ValueString(), info)})
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Invoke finished successfully", actionPath))
+}
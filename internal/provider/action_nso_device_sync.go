@@ -0,0 +1,132 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ action.ActionWithConfigure = &DeviceSyncAction{}
+
+func NewDeviceSyncFromAction() action.Action {
+	return &DeviceSyncAction{action: "sync-from"}
+}
+
+func NewDeviceSyncToAction() action.Action {
+	return &DeviceSyncAction{action: "sync-to"}
+}
+
+// DeviceSyncAction implements the nso_device_sync_from and nso_device_sync_to actions, which only differ in the
+// direction of the synchronization
+type DeviceSyncAction struct {
+	clients map[string]*restconf.Client
+	action  string
+}
+
+func (a *DeviceSyncAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	if a.action == "sync-from" {
+		resp.TypeName = req.ProviderTypeName + "_device_sync_from"
+	} else {
+		resp.TypeName = req.ProviderTypeName + "_device_sync_to"
+	}
+}
+
+func (a *DeviceSyncAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	description := "Synchronizes the configuration of a device from the device to NSO using the `sync-from` action."
+	if a.action == "sync-to" {
+		description = "Synchronizes the configuration of a device from NSO to the device using the `sync-to` action."
+	}
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: description,
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "The name of the device.",
+				Required:            true,
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Only report the configuration changes in CLI format without applying them.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *DeviceSyncAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (a *DeviceSyncAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config DeviceSync
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := a.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	actionPath := deviceActionPath(config.Device, a.action)
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Invoke", actionPath))
+
+	commitQueue := helpers.NewCommitQueue(client)
+	res, err := client.PostData(actionPath, config.toBody())
+	commitQueue.Add(res)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to invoke %s action, got error: %s", a.action, err))
+		return
+	}
+	if config.DryRun.ValueBool() {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Changes of %s on device %s:\n%s", a.action, config.Device.ValueString(), dryRunOutput(res.Res))})
+	} else if result, info := deviceActionResult(res.Res); !result {
+		resp.Diagnostics.AddError("Action failed", fmt.Sprintf("The %s action of device %s failed: %s", a.action, config.Device.ValueString(), info))
+		return
+	} else {
+		resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Device %s synchronized using %s", config.Device.ValueString(), a.action)})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Invoke finished successfully", actionPath))
+}
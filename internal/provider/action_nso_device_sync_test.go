@@ -0,0 +1,67 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNsoDeviceSyncAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		TerraformVersionChecks:   testAccActionVersionChecks,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsoDeviceSyncActionConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terraform_data.trigger", "input", "ce0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNsoDeviceSyncActionConfig() string {
+	return `
+	action "nso_device_sync_from" "test" {
+		config {
+			device = "ce0"
+		}
+	}
+
+	action "nso_device_sync_to" "test" {
+		config {
+			device  = "ce0"
+			dry_run = true
+		}
+	}
+
+	resource "terraform_data" "trigger" {
+		input = "ce0"
+		lifecycle {
+			action_trigger {
+				events  = [after_create]
+				actions = [action.nso_device_sync_from.test, action.nso_device_sync_to.test]
+			}
+		}
+	}
+	`
+}
@@ -0,0 +1,104 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ action.ActionWithConfigure = &PackagesReloadAction{}
+
+func NewPackagesReloadAction() action.Action {
+	return &PackagesReloadAction{}
+}
+
+type PackagesReloadAction struct {
+	clients map[string]*restconf.Client
+}
+
+func (a *PackagesReloadAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_packages_reload"
+}
+
+func (a *PackagesReloadAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reloads the NSO packages using the `packages reload` action, e.g. after new package versions have been installed.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"force": schema.BoolAttribute{
+				MarkdownDescription: "Reload the packages even if there are warnings, e.g. about removed YANG nodes which are in use.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *PackagesReloadAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (a *PackagesReloadAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config PackagesReload
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := a.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Invoke", packagesReloadPath))
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: "Reloading packages"})
+	res, err := client.PostData(packagesReloadPath, config.toBody())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to reload packages, got error: %s", err))
+		return
+	}
+	if failures := packagesReloadFailures(res.Res); len(failures) > 0 {
+		resp.Diagnostics.AddError("Action failed", fmt.Sprintf("Failed to reload packages:\n%s", strings.Join(failures, "\n")))
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: "Packages reloaded"})
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Invoke finished successfully", packagesReloadPath))
+}
@@ -0,0 +1,111 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ action.ActionWithConfigure = &ServiceRedeployAction{}
+
+func NewServiceRedeployAction() action.Action {
+	return &ServiceRedeployAction{}
+}
+
+type ServiceRedeployAction struct {
+	clients map[string]*restconf.Client
+}
+
+func (a *ServiceRedeployAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_redeploy"
+}
+
+func (a *ServiceRedeployAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Re-deploys a service instance using the `re-deploy` action, e.g. after the configuration of its devices has been changed outside of NSO.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "A RESTCONF path of the service instance, e.g. `tailf-ncs:services/l3vpn:vpn/l3vpn=vpn1`.",
+				Required:            true,
+			},
+			"reconcile": schema.BoolAttribute{
+				MarkdownDescription: "Reconcile the service, which takes ownership of existing device configuration created by the service.",
+				Optional:            true,
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Only report the configuration changes in CLI format without applying them.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *ServiceRedeployAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	a.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (a *ServiceRedeployAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config ServiceRedeploy
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := a.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	actionPath := Service{Path: config.Path}.getActionPath("re-deploy")
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Invoke", actionPath))
+
+	res, err := client.PostData(actionPath, config.toBody())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to re-deploy service, got error: %s", err))
+		return
+	}
+	if config.DryRun.ValueBool() {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Changes of re-deploy of service %s:\n%s", config.Path.ValueString(), dryRunOutput(res.Res))})
+	} else {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Service %s re-deployed", config.Path.ValueString())})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Invoke finished successfully", actionPath))
+}
@@ -0,0 +1,159 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &CommitQueueDataSource{}
+	_ datasource.DataSourceWithConfigure = &CommitQueueDataSource{}
+)
+
+func NewCommitQueueDataSource() datasource.DataSource {
+	return &CommitQueueDataSource{}
+}
+
+type CommitQueueDataSource struct {
+	clients map[string]*restconf.Client
+}
+
+func (d *CommitQueueDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_commit_queue"
+}
+
+func (d *CommitQueueDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source can read the items of the NSO commit queue. Completed items are removed from the queue.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The RESTCONF path.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return queue items with this status, e.g. `failed`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"queue_items": schema.ListNestedAttribute{
+				MarkdownDescription: "List of commit queue items.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Queue item ID.",
+							Computed:            true,
+						},
+						"tag": schema.StringAttribute{
+							MarkdownDescription: "Tag of the commit.",
+							Computed:            true,
+						},
+						"age": schema.Int64Attribute{
+							MarkdownDescription: "Time in seconds since the item was added to the queue.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the queue item, e.g. `waiting`, `executing`, `locked` or `failed`.",
+							Computed:            true,
+						},
+						"devices": schema.ListAttribute{
+							MarkdownDescription: "Devices affected by the queue item.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"completed_devices": schema.ListAttribute{
+							MarkdownDescription: "Devices which are already configured.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"failed_devices": schema.ListAttribute{
+							MarkdownDescription: "Devices which failed, including the reason if available.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CommitQueueDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (d *CommitQueueDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CommitQueue
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, ok := d.clients[config.Instance.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Read", config.getPath()))
+
+	res, err := d.clients[config.Instance.ValueString()].GetData(config.getPath(), restconf.Query("content", "nonconfig"))
+	if res.StatusCode == 404 {
+		config.QueueItems = make([]CommitQueueQueueItem, 0)
+	} else {
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to retrieve commit queue, got error: %s", err))
+			return
+		}
+
+		config.fromBody(ctx, res.Res)
+	}
+
+	config.Id = types.StringValue(config.getPath())
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Read finished successfully", config.getPath()))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -75,6 +75,18 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				MarkdownDescription: "Port for the management interface on the device. If this leaf is not configured, NCS will use a default value based on the type of device. For example, a NETCONF device uses port 830, a CLI device over SSH uses port 22, and an SNMP device uses port 161.",
 				Computed:            true,
 			},
+			"connect_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds for new connections. If this leaf is not configured, the value from the global settings is used.",
+				Computed:            true,
+			},
+			"read_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds used when reading data. If this leaf is not configured, the value from the global settings is used.",
+				Computed:            true,
+			},
+			"write_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds used when writing data. If this leaf is not configured, the value from the global settings is used.",
+				Computed:            true,
+			},
 			"authgroup": schema.StringAttribute{
 				MarkdownDescription: "The authentication credentials used when connecting to this managed device.",
 				Computed:            true,
@@ -67,12 +67,12 @@ func (d *DeviceGroupDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "Device group name.",
 				Required:            true,
 			},
-			"device_names": schema.ListAttribute{
+			"device_names": schema.SetAttribute{
 				MarkdownDescription: "A list of device names.",
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
-			"device_groups": schema.ListAttribute{
+			"device_groups": schema.SetAttribute{
 				MarkdownDescription: "A list of device groups.",
 				ElementType:         types.StringType,
 				Computed:            true,
@@ -28,12 +28,12 @@ import (
 func TestAccDataSourceNsoDeviceGroup(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceNsoDeviceGroupConfig,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("data.nso_device_group.test", "device_names.0", "ce0"),
+					resource.TestCheckTypeSetElemAttr("data.nso_device_group.test", "device_names.*", "ce0"),
 				),
 			},
 		},
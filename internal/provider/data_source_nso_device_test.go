@@ -28,13 +28,16 @@ import (
 func TestAccDataSourceNsoDevice(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceNsoDeviceConfig,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.nso_device.test", "address", "10.1.1.1"),
 					resource.TestCheckResourceAttr("data.nso_device.test", "port", "22"),
+					resource.TestCheckResourceAttr("data.nso_device.test", "connect_timeout", "30"),
+					resource.TestCheckResourceAttr("data.nso_device.test", "read_timeout", "30"),
+					resource.TestCheckResourceAttr("data.nso_device.test", "write_timeout", "30"),
 					resource.TestCheckResourceAttr("data.nso_device.test", "authgroup", "default"),
 					resource.TestCheckResourceAttr("data.nso_device.test", "admin_state", "locked"),
 					resource.TestCheckResourceAttr("data.nso_device.test", "cli_ned_id", "cisco-ios-cli-3.8:cisco-ios-cli-3.8"),
@@ -50,6 +53,9 @@ resource "nso_device" "test" {
 	name = "test-device01"
 	address = "10.1.1.1"
 	port = 22
+	connect_timeout = 30
+	read_timeout = 30
+	write_timeout = 30
 	authgroup = "default"
 	admin_state = "locked"
 	cli_ned_id = "cisco-ios-cli-3.8:cisco-ios-cli-3.8"
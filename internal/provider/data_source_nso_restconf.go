@@ -113,19 +113,7 @@ func (d *RestconfDataSource) Read(ctx context.Context, req datasource.ReadReques
 		state.Path = config.Path
 		state.Id = config.Path
 
-		attributes := make(map[string]attr.Value)
-
-		for attr, value := range res.Res.Get(helpers.LastElement(config.Path.ValueString())).Map() {
-			// handle empty maps
-			if value.IsObject() && len(value.Map()) == 0 {
-				attributes[attr] = types.StringValue("")
-			} else if value.Raw == "[null]" {
-				attributes[attr] = types.StringValue("")
-			} else {
-				attributes[attr] = types.StringValue(value.String())
-			}
-		}
-		state.Attributes = types.MapValueMust(types.StringType, attributes)
+		state.Attributes = restconfAttributes(res.Res.Get(helpers.LastElement(config.Path.ValueString())))
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Read finished successfully", config.Id.ValueString()))
@@ -0,0 +1,155 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &RollbacksDataSource{}
+	_ datasource.DataSourceWithConfigure = &RollbacksDataSource{}
+)
+
+func NewRollbacksDataSource() datasource.DataSource {
+	return &RollbacksDataSource{}
+}
+
+type RollbacksDataSource struct {
+	clients map[string]*restconf.Client
+}
+
+func (d *RollbacksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rollbacks"
+}
+
+func (d *RollbacksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source can read the rollback files of NSO, ordered from the most recent to the oldest commit.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The RESTCONF path.",
+				Computed:            true,
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Only return rollback files of commits with this label.",
+				Optional:            true,
+			},
+			"rollbacks": schema.ListNestedAttribute{
+				MarkdownDescription: "List of rollback files.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Rollback ID, `0` is the most recent commit. IDs change with every commit.",
+							Computed:            true,
+						},
+						"fixed_number": schema.Int64Attribute{
+							MarkdownDescription: "Fixed number of the rollback file, which does not change with subsequent commits.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the rollback file.",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "Label of the commit.",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Comment of the commit.",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "User who committed the transaction.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "Date and time of the commit.",
+							Computed:            true,
+						},
+						"via": schema.StringAttribute{
+							MarkdownDescription: "Northbound interface used for the commit.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RollbacksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (d *RollbacksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config Rollbacks
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, ok := d.clients[config.Instance.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Read", config.getPath()))
+
+	res, err := d.clients[config.Instance.ValueString()].GetData(config.getPath(), restconf.Query("content", "nonconfig"))
+	if res.StatusCode == 404 {
+		config.Rollbacks = make([]RollbacksEntry, 0)
+	} else {
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to retrieve rollback files, got error: %s", err))
+			return
+		}
+
+		config.fromBody(ctx, res.Res)
+	}
+
+	config.Id = types.StringValue(config.getPath())
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Read finished successfully", config.getPath()))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ ephemeral.EphemeralResourceWithConfigure = &RestconfEphemeralResource{}
+
+func NewRestconfEphemeralResource() ephemeral.EphemeralResource {
+	return &RestconfEphemeralResource{}
+}
+
+type RestconfEphemeralResource struct {
+	clients map[string]*restconf.Client
+}
+
+func (e *RestconfEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_restconf"
+}
+
+func (e *RestconfEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Retrieves one or more attributes via RESTCONF without storing them in the plan or state, e.g. secrets stored in NSO. If `input` is set, the YANG action at `path` is invoked and its output is returned instead, e.g. to decrypt credentials.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "A RESTCONF path.",
+				Required:            true,
+			},
+			"input": schema.MapAttribute{
+				MarkdownDescription: "Map of key-value pairs which represents the input leafs of the YANG action and its values. An empty map invokes an action without input.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Map of key-value pairs which represents the YANG leafs and its values.",
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (e *RestconfEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	e.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (e *RestconfEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config RestconfEphemeralModel
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := e.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Open", config.Path.ValueString()))
+
+	// The response carries secrets, which must not be written to the debug log or the audit log
+	method, body := "GET", ""
+	if !config.Input.IsNull() {
+		method, body = "POST", config.toBody(ctx)
+	}
+	res, _, err := helpers.DoEphemeral(ctx, client, method, restconf.RestconfDataEndpoint+"/"+config.Path.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to retrieve object, got error: %s", err))
+		return
+	}
+	config.fromBody(ctx, res.Res)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Open finished successfully", config.Path.ValueString()))
+
+	diags = resp.Result.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,114 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEphemeralResourceNsoRestconf(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccEphemeralProviderFactories(t),
+		TerraformVersionChecks:   testAccEphemeralVersionChecks,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralResourceNsoRestconfConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.host-key-verification", "reject-unknown"),
+				),
+			},
+		},
+	})
+}
+
+func TestRestconfEphemeralResource(t *testing.T) {
+	s := nsomock.NewServer(nsomock.WithAction("decrypt", func(path string, input map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"cleartext": "clear-" + input["name"].(string)}
+	}))
+	defer s.Close()
+	// The leaf names are not redacted by default, the secrets must neither be in the audit log nor the debug log
+	s.SetConfig("tailf-ncs:services/api-key=k1", `{"tailf-ncs:api-key":[{"name":"k1","key":"s3cret"}]}`)
+	checkLogs := testEphemeralLogs(t)
+
+	result, errs := testOpenEphemeralResource(t, s, "nso_restconf", map[string]tftypes.Value{
+		"path": tftypes.NewValue(tftypes.String, "tailf-ncs:services/api-key=k1"),
+	})
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	attributes := map[string]tftypes.Value{}
+	if err := result["attributes"].As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	if got := testString(t, attributes["key"]); got != "s3cret" {
+		t.Errorf("key = %q, want %q", got, "s3cret")
+	}
+
+	result, errs = testOpenEphemeralResource(t, s, "nso_restconf", map[string]tftypes.Value{
+		"path":  tftypes.NewValue(tftypes.String, "tailf-ncs:devices/authgroups/decrypt"),
+		"input": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "default")}),
+	})
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	attributes = map[string]tftypes.Value{}
+	if err := result["attributes"].As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	if got := testString(t, attributes["cleartext"]); got != "clear-default" {
+		t.Errorf("action output cleartext = %q, want %q", got, "clear-default")
+	}
+
+	_, errs = testOpenEphemeralResource(t, s, "nso_restconf", map[string]tftypes.Value{
+		"path": tftypes.NewValue(tftypes.String, "tailf-ncs:services/api-key=k2"),
+	})
+	if len(errs) != 1 || !strings.Contains(errs[0], "Failed to retrieve object") {
+		t.Errorf("want error for missing object, got %v", errs)
+	}
+
+	auditLog := checkLogs("s3cret", "clear-default")
+	if !strings.Contains(auditLog, `"path":"/restconf/data/tailf-ncs:devices/authgroups/decrypt"`) || !strings.Contains(auditLog, `"ephemeral":true`) {
+		t.Errorf("audit log: want ephemeral requests, got %s", auditLog)
+	}
+}
+
+const testAccEphemeralResourceNsoRestconfConfig = `
+resource "nso_restconf" "test" {
+	path = "tailf-ncs:ssh"
+	attributes = {
+		host-key-verification = "reject-unknown"
+	}
+}
+
+ephemeral "nso_restconf" "test" {
+	path = "tailf-ncs:ssh"
+	depends_on = [nso_restconf.test]
+}
+
+provider "echo" {
+	data = ephemeral.nso_restconf.test.attributes
+}
+
+resource "echo" "test" {}
+`
@@ -0,0 +1,110 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ ephemeral.EphemeralResourceWithConfigure = &SessionTokenEphemeralResource{}
+
+func NewSessionTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &SessionTokenEphemeralResource{}
+}
+
+type SessionTokenEphemeralResource struct {
+	clients map[string]*restconf.Client
+}
+
+func (e *SessionTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_token"
+}
+
+func (e *SessionTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Authenticates with the credentials of the provider configuration and returns the token of the RESTCONF session, which can be passed to other tools using the `X-Auth-Token` header. Token responses must be enabled by `/ncs-config/restconf/token-response/x-auth-token` in `ncs.conf`.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The session token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *SessionTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	e.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (e *SessionTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config SessionToken
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := e.clients[config.Instance.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Open", client.Url))
+
+	// The token is returned as header of any authenticated request, the RESTCONF root resource has a small response
+	_, header, err := helpers.DoEphemeral(ctx, client, "GET", "", "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to authenticate, got error: %s", err))
+		return
+	}
+	token := header.Get(sessionTokenHeader)
+	if token == "" {
+		resp.Diagnostics.AddError("Missing session token", fmt.Sprintf("NSO did not return a token in the %s header, token responses must be enabled by /ncs-config/restconf/token-response/x-auth-token in ncs.conf.", sessionTokenHeader))
+		return
+	}
+	config.Token = types.StringValue(token)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Open finished successfully", client.Url))
+
+	diags = resp.Result.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The mock server does not return session tokens, NSO must have token responses enabled in ncs.conf
+func TestAccEphemeralResourceNsoSessionToken(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccLivePreCheck(t) },
+		ProtoV6ProviderFactories: testAccEphemeralProviderFactories(t),
+		TerraformVersionChecks:   testAccEphemeralVersionChecks,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralResourceNsoSessionTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEphemeralResourceNsoSessionTokenConfig = `
+ephemeral "nso_session_token" "test" {}
+
+provider "echo" {
+	data = ephemeral.nso_session_token.test.token
+}
+
+resource "echo" "test" {}
+`
+
+func TestSessionTokenEphemeralResource(t *testing.T) {
+	s := nsomock.NewServer(nsomock.WithAuthToken("token1"))
+	defer s.Close()
+	checkLogs := testEphemeralLogs(t)
+
+	result, errs := testOpenEphemeralResource(t, s, "nso_session_token", nil)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if got := testString(t, result["token"]); got != "token1" {
+		t.Errorf("token = %q, want %q", got, "token1")
+	}
+
+	s.Token = ""
+	_, errs = testOpenEphemeralResource(t, s, "nso_session_token", nil)
+	if len(errs) != 1 || !strings.Contains(errs[0], "x-auth-token") {
+		t.Errorf("want missing token error, got %v", errs)
+	}
+
+	if auditLog := checkLogs("token1"); !strings.Contains(auditLog, `"ephemeral":true`) {
+		t.Errorf("audit log: want ephemeral requests, got %s", auditLog)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &DeviceConfigPathFunction{}
+
+func NewDeviceConfigPathFunction() function.Function {
+	return &DeviceConfigPathFunction{}
+}
+
+type DeviceConfigPathFunction struct{}
+
+func (f *DeviceConfigPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "device_config_path"
+}
+
+func (f *DeviceConfigPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the RESTCONF path of a device config",
+		MarkdownDescription: "Builds the RESTCONF path of the config of an NSO device, as used by the `id` of the `nso_device_config` resource. The device name is encoded, the path below the device config is appended as is and can be built using `restconf_path`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "device",
+				MarkdownDescription: "An NSO device name.",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "A RESTCONF path below the device config, an empty string refers to the device config itself.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DeviceConfigPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var device, path string
+
+	resp.Error = req.Arguments.Get(ctx, &device, &path)
+	if resp.Error != nil {
+		return
+	}
+
+	if device == "" {
+		resp.Error = function.NewArgumentFuncError(0, "device name must not be empty")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, helpers.DeviceConfigPath(device, path))
+}
@@ -0,0 +1,70 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &EncodeKeyFunction{}
+
+func NewEncodeKeyFunction() function.Function {
+	return &EncodeKeyFunction{}
+}
+
+type EncodeKeyFunction struct{}
+
+func (f *EncodeKeyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "encode_key"
+}
+
+func (f *EncodeKeyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encode the key of a list entry",
+		MarkdownDescription: "Encodes the key values of a YANG list entry for use in a RESTCONF path, e.g. `GigabitEthernet=${provider::nso::encode_key([\"0/1\"])}`. Multiple key values are separated by a comma (`,`).",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "values",
+				MarkdownDescription: "Key values in the order of the YANG list keys.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EncodeKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var values []string
+
+	resp.Error = req.Arguments.Get(ctx, &values)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(values) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "at least one key value is required")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, helpers.EncodeKey(values...))
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &RestconfPathFunction{}
+
+func NewRestconfPathFunction() function.Function {
+	return &RestconfPathFunction{}
+}
+
+type RestconfPathFunction struct{}
+
+func (f *RestconfPathFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "restconf_path"
+}
+
+func (f *RestconfPathFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build a RESTCONF path",
+		MarkdownDescription: "Builds a RESTCONF path from a list of segments. Each segment is a list with the node name, optionally prefixed by the YANG module name, followed by the key values of a list entry. Key values are encoded like the `id` of the resources, e.g. `/` is encoded as `%2F`.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "segments",
+				MarkdownDescription: "List of path segments, e.g. `[[\"tailf-ncs:devices\"], [\"device\", \"ce0\"]]`.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RestconfPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var segments [][]string
+
+	resp.Error = req.Arguments.Get(ctx, &segments)
+	if resp.Error != nil {
+		return
+	}
+
+	path, err := helpers.RestconfPath(segments)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, path)
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNsoFunctions(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value string
+		want  string
+		err   string
+	}{
+		{
+			name:  "device_config_path",
+			value: `provider::nso::device_config_path("ce/0", "tailf-ned-cisco-ios:hostname")`,
+			want:  "tailf-ncs:devices/device=ce%2F0/config/tailf-ned-cisco-ios:hostname",
+		},
+		{
+			name:  "device_config_path_empty",
+			value: `provider::nso::device_config_path("", "")`,
+			err:   `device name must not be empty`,
+		},
+		{
+			name:  "encode_key",
+			value: `provider::nso::encode_key(["0/1", "a,b", "c d+e"])`,
+			want:  "0%2F1,a%2Cb,c%20d%2Be",
+		},
+		{
+			name:  "encode_key_empty",
+			value: `provider::nso::encode_key([])`,
+			err:   `at least one key value is required`,
+		},
+		{
+			name:  "restconf_path",
+			value: `provider::nso::restconf_path([["tailf-ncs:devices"], ["device", "ce0"], ["config"], ["tailf-ned-cisco-ios:interface"], ["GigabitEthernet", "0/1"]])`,
+			want:  "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1",
+		},
+		{
+			name:  "restconf_path_no_name",
+			value: `provider::nso::restconf_path([["tailf-ncs:devices"], []])`,
+			err:   `segment 1 has no name`,
+		},
+		{
+			name:  "xpath_to_restconf",
+			value: `provider::nso::xpath_to_restconf("/ncs:devices/device[name='ce0']/config/tailf-ned-cisco-ios:interface/GigabitEthernet[name='0/1']")`,
+			want:  "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1",
+		},
+		{
+			name:  "xpath_to_restconf_position",
+			value: `provider::nso::xpath_to_restconf("/ncs:devices/device[1]")`,
+			err:   `unsupported predicate`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			step := resource.TestStep{
+				Config: `output "test" {
+					value = ` + tc.value + `
+				}`,
+			}
+			if tc.err != "" {
+				step.ExpectError = regexp.MustCompile(tc.err)
+			} else {
+				step.Check = resource.TestCheckOutput("test", tc.want)
+			}
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+				TerraformVersionChecks:   testAccFunctionVersionChecks,
+				Steps:                    []resource.TestStep{step},
+			})
+		})
+	}
+}
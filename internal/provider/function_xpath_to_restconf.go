@@ -0,0 +1,69 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ function.Function = &XPathToRestconfFunction{}
+
+func NewXPathToRestconfFunction() function.Function {
+	return &XPathToRestconfFunction{}
+}
+
+type XPathToRestconfFunction struct{}
+
+func (f *XPathToRestconfFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "xpath_to_restconf"
+}
+
+func (f *XPathToRestconfFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert an XPath to a RESTCONF path",
+		MarkdownDescription: "Converts an XPath with list key predicates, e.g. `/ncs:devices/device[name='ce0']/config`, to a RESTCONF path. The key predicates of a list entry have to be in the order of the YANG list keys, key values are encoded like the `id` of the resources. The `ncs` prefix is replaced by the module name `tailf-ncs`, other prefixes have to be module names.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "xpath",
+				MarkdownDescription: "An XPath with list key predicates.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *XPathToRestconfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var xpath string
+
+	resp.Error = req.Arguments.Get(ctx, &xpath)
+	if resp.Error != nil {
+		return
+	}
+
+	path, err := helpers.XPathToRestconf(xpath)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, path)
+}
@@ -0,0 +1,384 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netascode/go-restconf"
+)
+
+const (
+	AuditLogFormatNDJSON = "ndjson"
+	AuditLogFormatHAR    = "har"
+)
+
+// AuditLog writes every RESTCONF request and response of the provider to a file, either as one JSON object per line
+// or as HTTP archive. The log is shared by the clients of all NSO instances.
+type AuditLog struct {
+	Path    string
+	Format  string
+	Version string
+
+	mu              sync.Mutex
+	sensitiveLeaves []string
+	sensitive       *regexp.Regexp
+	file            *os.File
+	// harEnd is the offset of the closing brackets of the HTTP archive, which are overwritten by the next entry
+	harEnd     int64
+	harEntries int
+	// err is the first error writing to the log, no further requests are sent afterwards
+	err error
+}
+
+// AuditLogEntry is a single line of a NDJSON audit log
+type AuditLogEntry struct {
+	Time         time.Time `json:"time"`
+	Instance     string    `json:"instance,omitempty"`
+	Method       string    `json:"method"`
+	Path         string    `json:"path"`
+	Query        string    `json:"query,omitempty"`
+	Status       int       `json:"status"`
+	DurationMs   float64   `json:"duration_ms"`
+	RequestBody  string    `json:"request_body,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	// Ephemeral requests of ephemeral resources are recorded without bodies, as they carry secrets
+	Ephemeral bool `json:"ephemeral,omitempty"`
+}
+
+var (
+	auditLogsMu sync.Mutex
+	// auditLogs are the open audit logs by absolute path. Provider configurations of the same process, e.g. aliased
+	// providers, share the log of a path, as they would overwrite each other's HAR entries otherwise.
+	auditLogs = map[string]*AuditLog{}
+)
+
+// NewAuditLog opens the audit log file, or returns the log already opened for the path by another provider
+// configuration, which redacts the sensitive leaves of both. NDJSON logs are appended to, as plan and apply run in
+// separate provider processes. HAR files hold a single document and are truncated, they only contain the requests of
+// the last provider process, e.g. of the apply but not of the plan.
+func NewAuditLog(path, format, version string, sensitiveLeaves []string) (*AuditLog, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	auditLogsMu.Lock()
+	defer auditLogsMu.Unlock()
+	if l, ok := auditLogs[absPath]; ok {
+		if l.Format != format {
+			return nil, fmt.Errorf("audit log %s is already used with format %q by another provider configuration", path, l.Format)
+		}
+		l.addSensitiveLeaves(sensitiveLeaves)
+		return l, nil
+	}
+
+	l := &AuditLog{Path: path, Format: format, Version: version}
+	l.addSensitiveLeaves(sensitiveLeaves)
+	switch format {
+	case AuditLogFormatNDJSON:
+		l.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	case AuditLogFormatHAR:
+		l.file, err = os.OpenFile(path, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
+		if err == nil {
+			err = l.startHAR()
+		}
+	default:
+		return nil, fmt.Errorf("invalid audit log format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	auditLogs[absPath] = l
+	return l, nil
+}
+
+func (l *AuditLog) addSensitiveLeaves(names []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sensitiveLeaves = append(l.sensitiveLeaves, names...)
+	l.sensitive = SensitiveLeavesWith(l.sensitiveLeaves)
+}
+
+// ConfigureAuditLog installs a transport in the HTTP client of a RESTCONF client which writes all requests to the
+// audit log. It has to be configured before the commit queue to log the commit queue parameters.
+func ConfigureAuditLog(client *restconf.Client, instance string, log *AuditLog) {
+	client.HttpClient.Transport = &AuditLogTransport{
+		Log:      log,
+		Instance: instance,
+		next:     client.HttpClient.Transport,
+	}
+}
+
+type AuditLogTransport struct {
+	Log      *AuditLog
+	Instance string
+	next     http.RoundTripper
+}
+
+func (t *AuditLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	if err := t.Log.failed(); err != nil {
+		return nil, fmt.Errorf("failed to write audit log, request not sent: %w", err)
+	}
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	var resBody []byte
+	if err == nil {
+		resBody, err = io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(resBody))
+	}
+	// The response is returned even if it cannot be logged, as the request has been processed by NSO already
+	t.Log.write(t.Instance, req, reqBody, res, resBody, start, time.Since(start), err)
+	return res, err
+}
+
+func (l *AuditLog) failed() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+func (l *AuditLog) write(instance string, req *http.Request, reqBody []byte, res *http.Response, resBody []byte, start time.Time, duration time.Duration, reqErr error) {
+	ephemeral := IsEphemeral(req.Context())
+	if ephemeral {
+		reqBody, resBody = nil, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := AuditLogEntry{
+		Time:         start.UTC(),
+		Instance:     instance,
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Query:        req.URL.RawQuery,
+		DurationMs:   float64(duration.Microseconds()) / 1000,
+		RequestBody:  RedactBody(reqBody, l.sensitive),
+		ResponseBody: RedactBody(resBody, l.sensitive),
+		Ephemeral:    ephemeral,
+	}
+	if res != nil {
+		entry.Status = res.StatusCode
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+
+	var err error
+	if l.Format == AuditLogFormatHAR {
+		err = l.appendHAR(newHAREntry(entry, req, res))
+	} else {
+		var line []byte
+		line, err = json.Marshal(entry)
+		if err == nil {
+			_, err = l.file.Write(append(line, '\n'))
+		}
+	}
+	if err != nil && l.err == nil {
+		l.err = err
+	}
+}
+
+const harSuffix = "]}}\n"
+
+// startHAR writes an HTTP archive without entries
+func (l *AuditLog) startHAR() error {
+	content, err := json.Marshal(harDocument{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "terraform-provider-nso", Version: l.Version},
+		Entries: []harEntry{},
+	}})
+	if err != nil {
+		return err
+	}
+	l.harEnd = int64(len(content) - len(harSuffix) + 1)
+	_, err = l.file.WriteAt(append(content, '\n'), 0)
+	return err
+}
+
+// appendHAR overwrites the closing brackets of the HTTP archive with the entry followed by the closing brackets,
+// which keeps the file valid if the provider is terminated without rewriting previous entries
+func (l *AuditLog) appendHAR(e harEntry) error {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if l.harEntries > 0 {
+		content = append([]byte{','}, content...)
+	}
+	if _, err := l.file.WriteAt(append(content, harSuffix...), l.harEnd); err != nil {
+		return err
+	}
+	l.harEnd += int64(len(content))
+	l.harEntries++
+	return nil
+}
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// newHAREntry converts an audit log entry, the Authorization header and session tokens are never included
+func newHAREntry(entry AuditLogEntry, req *http.Request, res *http.Response) harEntry {
+	u := *req.URL
+	u.User = nil
+	e := harEntry{
+		StartedDateTime: entry.Time.Format(time.RFC3339Nano),
+		Time:            entry.DurationMs,
+		Request: harRequest{
+			Method:      entry.Method,
+			URL:         u.String(),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(entry.RequestBody),
+		},
+		Response: harResponse{
+			Status:      entry.Status,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			Content:     harContent{Size: len(entry.ResponseBody), Text: entry.ResponseBody},
+			HeadersSize: -1,
+			BodySize:    len(entry.ResponseBody),
+		},
+		Timings: harTimings{Send: -1, Wait: entry.DurationMs, Receive: -1},
+		Comment: entry.Error,
+	}
+	if entry.Instance != "" {
+		e.Comment = strings.TrimSpace(fmt.Sprintf("Instance: %s %s", entry.Instance, entry.Error))
+	}
+	query := u.Query()
+	for _, name := range SortedKeys(query) {
+		for _, value := range query[name] {
+			e.Request.QueryString = append(e.Request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	if entry.RequestBody != "" {
+		e.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: entry.RequestBody}
+	}
+	if res != nil {
+		e.Response.StatusText = http.StatusText(res.StatusCode)
+		e.Response.Headers = harHeaders(res.Header)
+		e.Response.Content.MimeType = res.Header.Get("Content-Type")
+	}
+	return e
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for _, name := range SortedKeys(h) {
+		if name == "Authorization" || name == "Cookie" || name == "Set-Cookie" || name == "X-Auth-Token" {
+			continue
+		}
+		for _, value := range h[name] {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	return headers
+}
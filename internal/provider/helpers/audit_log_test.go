@@ -0,0 +1,196 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/netascode/go-restconf"
+)
+
+func newAuditLogClient(t *testing.T, s *nsomock.Server, log *AuditLog) *restconf.Client {
+	t.Helper()
+	client, err := restconf.NewClient(s.URL, s.Username, s.Password, true, restconf.MaxRetries(0), restconf.SkipDiscovery("/restconf", true))
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	ConfigureAuditLog(client, "lab", log)
+	ConfigureCommitQueue(client, "bypass", "", 0)
+	return client
+}
+
+func TestAuditLogNDJSON(t *testing.T) {
+	s := nsomock.NewServer(nsomock.WithCredentials("admin", "nso-password"))
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+	// existing logs are appended to
+	os.WriteFile(path, []byte(`{"method":"GET"}`+"\n"), 0600)
+
+	log, err := NewAuditLog(path, AuditLogFormatNDJSON, "test", []string{"community"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newAuditLogClient(t, s, log)
+	client.PutData("tailf-ncs:devices/authgroups/group=g1", `{"tailf-ncs:group":{"name":"g1","default-map":{"remote-password":"lab-secret"},"snmp":{"tailf-ned:community":"public","counter":18446744073709551615}}}`)
+	client.GetData("tailf-ncs:devices/device=missing")
+
+	file, _ := os.Open(path)
+	defer file.Close()
+	var entries []AuditLogEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid line %s: %s", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	put, get := entries[1], entries[2]
+	if put.Method != "PUT" || put.Path != "/restconf/data/tailf-ncs:devices/authgroups/group=g1" || put.Query != "commit-queue=bypass" || put.Status != 201 || put.Instance != "lab" {
+		t.Errorf("unexpected entry %+v", put)
+	}
+	for _, secret := range []string{"lab-secret", "public"} {
+		if strings.Contains(put.RequestBody, secret) {
+			t.Errorf("request body contains %q: %s", secret, put.RequestBody)
+		}
+	}
+	if !strings.Contains(put.RequestBody, "18446744073709551615") {
+		t.Errorf("request body lost number precision: %s", put.RequestBody)
+	}
+	if get.Method != "GET" || get.Status != 404 || get.Query != "" || get.ResponseBody == "" || get.DurationMs <= 0 {
+		t.Errorf("unexpected entry %+v", get)
+	}
+}
+
+func TestAuditLogHAR(t *testing.T) {
+	s := nsomock.NewServer(nsomock.WithCredentials("admin", "nso-password"), nsomock.WithAuthToken("nso-token"))
+	defer s.Close()
+	s.SetConfig("tailf-ncs:devices", `{"tailf-ncs:devices":{"global-settings":{}}}`)
+	path := filepath.Join(t.TempDir(), "audit.har")
+	os.WriteFile(path, []byte("previous content which is longer than an empty archive"), 0600)
+
+	log, err := NewAuditLog(path, AuditLogFormatHAR, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(path)
+	var har harDocument
+	if err := json.Unmarshal(content, &har); err != nil || len(har.Log.Entries) != 0 {
+		t.Fatalf("invalid empty archive %s: %v", content, err)
+	}
+
+	client := newAuditLogClient(t, s, log)
+	client.PatchData("tailf-ncs:devices", `{"tailf-ncs:devices":{"global-settings":{"connect-timeout":25}}}`)
+	first, _ := os.ReadFile(path)
+	client.GetData("tailf-ncs:devices/global-settings")
+
+	content, _ = os.ReadFile(path)
+	// Entries are appended, previous entries are not rewritten
+	if !bytes.HasPrefix(content, bytes.TrimSuffix(first, []byte(harSuffix))) {
+		t.Errorf("archive was rewritten:\n%s\n%s", first, content)
+	}
+	if err := json.Unmarshal(content, &har); err != nil {
+		t.Fatalf("invalid archive %s: %v", content, err)
+	}
+	if strings.Contains(string(content), "Authorization") || strings.Contains(string(content), "nso-password") || strings.Contains(string(content), "nso-token") {
+		t.Errorf("archive contains credentials: %s", content)
+	}
+	if len(har.Log.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(har.Log.Entries))
+	}
+	patch := har.Log.Entries[0]
+	if patch.Request.Method != "PATCH" || patch.Request.PostData == nil || len(patch.Request.QueryString) != 1 || patch.Response.Status != 204 || patch.Comment != "Instance: lab" {
+		t.Errorf("unexpected entry %+v", patch)
+	}
+	get := har.Log.Entries[1]
+	if get.Response.Status != 200 || !strings.Contains(get.Response.Content.Text, "connect-timeout") {
+		t.Errorf("unexpected entry %+v", get)
+	}
+}
+
+func TestAuditLogShared(t *testing.T) {
+	s := nsomock.NewServer()
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "audit.har")
+
+	// aliased providers open the same log
+	first, err := NewAuditLog(path, AuditLogFormatHAR, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstClient := newAuditLogClient(t, s, first)
+	firstClient.GetData("tailf-ncs:devices/device=ce0")
+	second, err := NewAuditLog(filepath.Join(filepath.Dir(path), ".", "audit.har"), AuditLogFormatHAR, "test", []string{"community"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatal("audit log of the same path is not shared")
+	}
+	newAuditLogClient(t, s, second).PutData("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":[{"name":"ce0","tailf-ned:community":"public"}]}`)
+	firstClient.GetData("tailf-ncs:devices/device=ce0")
+
+	content, _ := os.ReadFile(path)
+	var har harDocument
+	if err := json.Unmarshal(content, &har); err != nil {
+		t.Fatalf("invalid archive %s: %v", content, err)
+	}
+	if len(har.Log.Entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(har.Log.Entries))
+	}
+	// the sensitive leaves of all provider configurations are redacted
+	if strings.Contains(string(content), "public") {
+		t.Errorf("archive contains sensitive leaf: %s", content)
+	}
+
+	if _, err := NewAuditLog(path, AuditLogFormatNDJSON, "test", nil); err == nil {
+		t.Error("want error opening a shared audit log with a different format")
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		leaves []string
+		body   string
+		want   string
+	}{
+		{"empty", nil, "", ""},
+		{"not json", nil, "password=secret", "password=secret"},
+		{"normalized", nil, `{"b":1,"a":{"d":[1,2],"c":true}}`, `{"a":{"c":true,"d":[1,2]},"b":1}`},
+		{"default leaves", nil, `{"group":[{"remote-password":"x","remote-name":"y","ssh":{"private-key":{"name":"k"}}}]}`, `{"group":[{"remote-name":"y","remote-password":"REDACTED","ssh":{"private-key":{"name":"k"}}}]}`},
+		{"additional leaves", []string{"community"}, `{"tailf-ned:community":"public","community-name":"x","key":"y"}`, `{"community-name":"x","key":"y","tailf-ned:community":"REDACTED"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactBody([]byte(tt.body), SensitiveLeavesWith(tt.leaves)); got != tt.want {
+				t.Errorf("RedactBody() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	CommitQueueItemPath       = "tailf-ncs:devices/commit-queue/queue-item"
+	DefaultCommitQueueTimeout = 600 * time.Second
+)
+
+var commitQueuePollInterval = 2 * time.Second
+
+// CommitQueueTransport adds the commit queue query parameters to all write requests of a RESTCONF client and holds
+// the time to wait for queue items to complete.
+type CommitQueueTransport struct {
+	Mode        string
+	ErrorOption string
+	Timeout     time.Duration
+	next        http.RoundTripper
+}
+
+// ConfigureCommitQueue installs a CommitQueueTransport in the HTTP client of a RESTCONF client. Empty values keep the
+// commit queue settings of NSO.
+func ConfigureCommitQueue(client *restconf.Client, mode, errorOption string, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultCommitQueueTimeout
+	}
+	client.HttpClient.Transport = &CommitQueueTransport{
+		Mode:        mode,
+		ErrorOption: errorOption,
+		Timeout:     timeout,
+		next:        client.HttpClient.Transport,
+	}
+}
+
+func (t *CommitQueueTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Actions are invoked using POST requests and do not support commit queue parameters
+	if req.Method == "PATCH" || req.Method == "PUT" || req.Method == "DELETE" {
+		q := req.URL.Query()
+		if t.Mode != "" {
+			q.Set("commit-queue", t.Mode)
+		}
+		if t.ErrorOption != "" {
+			q.Set("commit-queue-error-option", t.ErrorOption)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+	return t.next.RoundTrip(req)
+}
+
+func commitQueueSettings(client *restconf.Client) *CommitQueueTransport {
+	if t, ok := client.HttpClient.Transport.(*CommitQueueTransport); ok {
+		return t
+	}
+	return &CommitQueueTransport{Timeout: DefaultCommitQueueTimeout}
+}
+
+// CommitQueue collects the commit queue items created by the write requests of a single operation, which is needed
+// as NSO returns before the devices are configured if the commit queue is used.
+type CommitQueue struct {
+	client *restconf.Client
+	items  []int64
+}
+
+func NewCommitQueue(client *restconf.Client) *CommitQueue {
+	return &CommitQueue{client: client}
+}
+
+// Add records the commit queue item of a write request or of the output of a service action like un-deploy, if any.
+// Items which already completed, e.g. when using the sync mode, are ignored.
+func (cq *CommitQueue) Add(res restconf.Res) {
+	result := res.Res.Get("*:result.commit-queue")
+	if !result.Exists() {
+		result = res.Res.Get("*:output.commit-queue")
+	}
+	if !result.Get("id").Exists() || result.Get("status").String() == "completed" {
+		return
+	}
+	cq.items = append(cq.items, result.Get("id").Int())
+}
+
+// Wait polls the recorded commit queue items until they are completed. Failed and locked items result in an error
+// naming the failed devices.
+func (cq *CommitQueue) Wait(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(cq.items) == 0 {
+		return diags
+	}
+	settings := commitQueueSettings(cq.client)
+	deadline := time.Now().Add(settings.Timeout)
+	for _, id := range cq.items {
+		itemPath := fmt.Sprintf("%s=%d", CommitQueueItemPath, id)
+		tflog.Debug(ctx, fmt.Sprintf("%s: Waiting for commit queue item", itemPath))
+		for {
+			res, err := cq.client.GetData(itemPath, restconf.Query("content", "nonconfig"))
+			// Completed items are removed from the queue
+			if res.StatusCode == 404 {
+				break
+			}
+			if err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Failed to retrieve commit queue item %d, got error: %s", id, err))
+				return diags
+			}
+			item := res.Res.Get("*:queue-item.0")
+			status := item.Get("status").String()
+			if status == "completed" {
+				break
+			}
+			if status == "failed" || status == "locked" {
+				detail := fmt.Sprintf("Commit queue item %d is %s.", id, status)
+				if devices := CommitQueueFailedDevices(item); len(devices) > 0 {
+					detail += fmt.Sprintf(" Failed devices: %s.", strings.Join(devices, ", "))
+				} else if devices := CommitQueueDevices(item.Get("devices")); len(devices) > 0 {
+					detail += fmt.Sprintf(" Affected devices: %s.", strings.Join(devices, ", "))
+				}
+				if settings.ErrorOption == "rollback-on-error" {
+					detail += " The configuration changes have been rolled back."
+				}
+				diags.AddError("Commit queue item failed", detail)
+				return diags
+			}
+			if time.Now().After(deadline) {
+				diags.AddError("Commit queue timeout", fmt.Sprintf("Commit queue item %d did not complete within %s, last status: %s.", id, settings.Timeout, status))
+				return diags
+			}
+			select {
+			case <-ctx.Done():
+				diags.AddError("Commit queue wait cancelled", fmt.Sprintf("Waiting for commit queue item %d was cancelled: %s", id, ctx.Err()))
+				return diags
+			case <-time.After(commitQueuePollInterval):
+			}
+		}
+	}
+	return diags
+}
+
+// CommitQueueDevices returns the device names of a leaf-list or a list keyed by name.
+func CommitQueueDevices(res gjson.Result) []string {
+	devices := make([]string, 0)
+	res.ForEach(func(_, v gjson.Result) bool {
+		if v.IsObject() {
+			devices = append(devices, v.Get("name").String())
+		} else {
+			devices = append(devices, v.String())
+		}
+		return true
+	})
+	return devices
+}
+
+// CommitQueueFailedDevices returns the failed devices of a queue item including the reason, if available.
+func CommitQueueFailedDevices(item gjson.Result) []string {
+	devices := make([]string, 0)
+	item.Get("failed").ForEach(func(_, v gjson.Result) bool {
+		if reason := v.Get("reason"); v.IsObject() && reason.Exists() {
+			devices = append(devices, fmt.Sprintf("%s (%s)", v.Get("name").String(), reason.String()))
+		} else if v.IsObject() {
+			devices = append(devices, v.Get("name").String())
+		} else {
+			devices = append(devices, v.String())
+		}
+		return true
+	})
+	return devices
+}
@@ -53,3 +53,50 @@ func (d *AttributeDescription) AddFloatRangeDescription(min, max float64) *Attri
 	d.String = fmt.Sprintf("%s\n  - Range: `%v`-`%v`", d.String, min, max)
 	return d
 }
+
+func (d *AttributeDescription) AddConflictsWithDescription(attributes ...string) *AttributeDescription {
+	d.String = fmt.Sprintf("%s\n  - Conflicts with: %s", d.String, quoteNames(attributes))
+	return d
+}
+
+func (d *AttributeDescription) AddExactlyOneOfDescription(attributes ...string) *AttributeDescription {
+	d.String = fmt.Sprintf("%s\n  - Exactly one of this attribute and %s must be configured", d.String, quoteNames(attributes))
+	return d
+}
+
+func (d *AttributeDescription) AddAtLeastOneOfDescription(attributes ...string) *AttributeDescription {
+	d.String = fmt.Sprintf("%s\n  - At least one of this attribute and %s must be configured", d.String, quoteNames(attributes))
+	return d
+}
+
+func (d *AttributeDescription) AddAlsoRequiresDescription(attributes ...string) *AttributeDescription {
+	d.String = fmt.Sprintf("%s\n  - Requires: %s", d.String, quoteNames(attributes))
+	return d
+}
+
+func (d *AttributeDescription) AddWhenDescription(xpath string) *AttributeDescription {
+	d.String = fmt.Sprintf("%s\n  - Only applicable when: `%s`", d.String, xpath)
+	return d
+}
+
+func (d *AttributeDescription) AddMustDescription(xpath string) *AttributeDescription {
+	d.String = fmt.Sprintf("%s\n  - Must satisfy: `%s`", d.String, xpath)
+	return d
+}
+
+func (d *AttributeDescription) AddReferenceDescription(target, resource string) *AttributeDescription {
+	if resource != "" {
+		d.String = fmt.Sprintf("%s\n  - Must reference an existing [`nso_%s`](../resources/%s) entry", d.String, resource, resource)
+	} else {
+		d.String = fmt.Sprintf("%s\n  - Must reference an existing `%s` entry", d.String, target)
+	}
+	return d
+}
+
+func quoteNames(names []string) string {
+	v := make([]string, len(names))
+	for i, name := range names {
+		v[i] = fmt.Sprintf("`%s`", name)
+	}
+	return strings.Join(v, ", ")
+}
@@ -0,0 +1,135 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+	"github.com/tidwall/gjson"
+)
+
+type ephemeralContextKey struct{}
+
+// EphemeralContext marks the requests of ephemeral resources, the audit log records them without request and
+// response bodies.
+func EphemeralContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ephemeralContextKey{}, true)
+}
+
+// IsEphemeral reports whether a request was sent with an EphemeralContext.
+func IsEphemeral(ctx context.Context) bool {
+	ephemeral, _ := ctx.Value(ephemeralContextKey{}).(bool)
+	return ephemeral
+}
+
+// DoEphemeral sends a request of an ephemeral resource to a path relative to the RESTCONF endpoint and returns the
+// response including its headers. Unlike restconf.Client.Do, which writes all request and response bodies to the
+// debug log, the bodies are never logged. Connection errors and transient errors are retried using the backoff
+// settings of the client like restconf.Client.Do does. Requests are not serialized with the write requests of the
+// client, like GET requests, which is fine for actions returning secrets as they do not change the configuration.
+func DoEphemeral(ctx context.Context, client *restconf.Client, method, uri, body string) (restconf.Res, http.Header, error) {
+	if err := client.Discovery(); err != nil {
+		return restconf.Res{}, nil, err
+	}
+	ctx = EphemeralContext(ctx)
+	for attempts := 0; ; attempts++ {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req := client.NewReq(method, uri, reader)
+		tflog.Debug(ctx, fmt.Sprintf("HTTP Request: %s, %s, body omitted", method, req.HttpReq.URL))
+		httpRes, err := client.HttpClient.Do(req.HttpReq.WithContext(ctx))
+		if err != nil {
+			if ctx.Err() == nil && client.Backoff(attempts) {
+				continue
+			}
+			return restconf.Res{}, nil, err
+		}
+		bodyBytes, err := io.ReadAll(httpRes.Body)
+		httpRes.Body.Close()
+		if err != nil {
+			if ctx.Err() == nil && client.Backoff(attempts) {
+				continue
+			}
+			return restconf.Res{}, nil, err
+		}
+		res := restconf.Res{StatusCode: httpRes.StatusCode}
+		tflog.Debug(ctx, fmt.Sprintf("HTTP Response: StatusCode %v, body omitted", httpRes.StatusCode))
+		if httpRes.StatusCode >= 200 && httpRes.StatusCode <= 299 {
+			res.Res = gjson.ParseBytes(bodyBytes)
+			return res, httpRes.Header, nil
+		}
+		res.Errors = ephemeralErrors(bodyBytes)
+		if ephemeralTransientError(res) && ctx.Err() == nil && client.Backoff(attempts) {
+			continue
+		}
+		return res, httpRes.Header, fmt.Errorf("HTTP Request failed: StatusCode %v, RESTCONF errors %+v", res.StatusCode, res.Errors)
+	}
+}
+
+// ephemeralErrors parses the RESTCONF errors of a response, which may use a module prefix or not
+func ephemeralErrors(body []byte) restconf.ErrorsModel {
+	var errors restconf.ErrorsRootModel
+	if json.Unmarshal(body, &errors) == nil && len(errors.Errors.Error) > 0 {
+		return errors.Errors
+	}
+	var namespaceErrors restconf.ErrorsRootNamespaceModel
+	json.Unmarshal(body, &namespaceErrors)
+	return namespaceErrors.Errors
+}
+
+// ephemeralTransientError reports whether a response matches one of restconf.TransientErrors, which are retried
+func ephemeralTransientError(res restconf.Res) bool {
+	errors := res.Errors.Error
+	if len(errors) == 0 {
+		errors = []restconf.ErrorModel{{}}
+	}
+	for _, resError := range errors {
+		for _, transient := range restconf.TransientErrors {
+			if transient.StatusCode != 0 && transient.StatusCode != res.StatusCode {
+				continue
+			}
+			if matchTransient(transient.ErrorType, resError.ErrorType) &&
+				matchTransient(transient.ErrorTag, resError.ErrorTag) &&
+				matchTransient(transient.ErrorAppTag, resError.ErrorAppTag) &&
+				matchTransient(transient.ErrorPath, resError.ErrorPath) &&
+				matchTransient(transient.ErrorMessage, resError.ErrorMessage) &&
+				matchTransient(transient.ErrorInfo, resError.ErrorInfo) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchTransient(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := regexp.MatchString(pattern, value)
+	return ok
+}
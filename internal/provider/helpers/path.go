@@ -0,0 +1,169 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// EncodeKey encodes the key values of a list entry for use in a RESTCONF path, multiple keys are separated by a comma.
+// All characters except unreserved ones are percent-encoded as required by RFC 8040 section 3.5.3, including a space,
+// as NSO reads a "+" as literal plus.
+func EncodeKey(values ...string) string {
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		encoded[i] = strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+	}
+	return strings.Join(encoded, ",")
+}
+
+// PathKeys extracts the key values of a RESTCONF path built from a format with "%v" placeholders, e.g.
+// "tailf-ncs:devices/device=%v". The keys are returned unescaped, nil if the path does not match the format.
+func PathKeys(format, path string) []string {
+	literals := strings.Split(format, "%v")
+	if !strings.HasPrefix(path, literals[0]) {
+		return nil
+	}
+	rest := path[len(literals[0]):]
+	keys := make([]string, 0, len(literals)-1)
+	for i, literal := range literals[1:] {
+		end := len(rest)
+		if literal != "" || i < len(literals)-2 {
+			end = strings.Index(rest, literal)
+			if end < 0 {
+				return nil
+			}
+		}
+		key, err := url.PathUnescape(rest[:end])
+		if err != nil {
+			return nil
+		}
+		keys = append(keys, key)
+		rest = rest[end+len(literal):]
+	}
+	if rest != "" {
+		return nil
+	}
+	return keys
+}
+
+// RestconfPath builds a RESTCONF path from segments, each segment is a node name optionally followed by the key values
+// of a list entry
+func RestconfPath(segments [][]string) (string, error) {
+	elements := make([]string, len(segments))
+	for i, segment := range segments {
+		if len(segment) == 0 || segment[0] == "" {
+			return "", fmt.Errorf("segment %d has no name", i)
+		}
+		if strings.ContainsAny(segment[0], "/=,") {
+			return "", fmt.Errorf("segment %d: invalid name %q", i, segment[0])
+		}
+		elements[i] = segment[0]
+		if len(segment) > 1 {
+			elements[i] += "=" + EncodeKey(segment[1:]...)
+		}
+	}
+	return strings.Join(elements, "/"), nil
+}
+
+// DeviceConfigPath returns the RESTCONF path of the config of a device, the optional path below the config is
+// appended as is
+func DeviceConfigPath(device, path string) string {
+	if path != "" {
+		return "tailf-ncs:devices/device=" + EncodeKey(device) + "/config/" + path
+	}
+	return "tailf-ncs:devices/device=" + EncodeKey(device) + "/config"
+}
+
+// XPathModules maps the YANG prefixes used in NSO XPath expressions to the module names required by RESTCONF
+var XPathModules = map[string]string{
+	"ncs": "tailf-ncs",
+}
+
+// XPathToRestconf converts an XPath with list key predicates, e.g. "/ncs:devices/device[name='ce0']/config", to a
+// RESTCONF path. The predicates of a list entry have to be in the order of the list keys. Prefixes are replaced by
+// module names using XPathModules and omitted if the module does not change.
+func XPathToRestconf(xpath string) (string, error) {
+	var segments [][]string
+	var module string
+	rest := strings.TrimPrefix(strings.TrimSpace(xpath), "/")
+	if rest == "" {
+		return "", fmt.Errorf("empty xpath")
+	}
+	for rest != "" {
+		end := strings.IndexAny(rest, "/[")
+		if end < 0 {
+			end = len(rest)
+		}
+		segment := []string{strings.TrimSpace(rest[:end])}
+		if segment[0] == "" {
+			return "", fmt.Errorf("empty path element in %q", xpath)
+		}
+		if prefix, name, ok := strings.Cut(segment[0], ":"); ok {
+			if m, ok := XPathModules[prefix]; ok {
+				prefix = m
+			}
+			if prefix == module {
+				segment[0] = name
+			} else {
+				segment[0] = prefix + ":" + name
+			}
+			module = prefix
+		}
+		rest = rest[end:]
+		for strings.HasPrefix(rest, "[") {
+			value, n, err := xpathPredicate(rest)
+			if err != nil {
+				return "", fmt.Errorf("%s in %q", err, xpath)
+			}
+			segment = append(segment, value)
+			rest = rest[n:]
+		}
+		if rest != "" && !strings.HasPrefix(rest, "/") {
+			return "", fmt.Errorf("unexpected %q in %q", rest, xpath)
+		}
+		rest = strings.TrimPrefix(rest, "/")
+		segments = append(segments, segment)
+	}
+	return RestconfPath(segments)
+}
+
+// xpathPredicate parses a key predicate like "[name='ce0']" at the start of s and returns the key value and the length
+// of the predicate
+func xpathPredicate(s string) (string, int, error) {
+	eq := strings.Index(s, "=")
+	if eq < 0 || strings.ContainsAny(s[1:eq], "[]'\"") || strings.TrimSpace(s[1:eq]) == "" {
+		return "", 0, fmt.Errorf("unsupported predicate")
+	}
+	value := strings.TrimLeft(s[eq+1:], " ")
+	offset := len(s) - len(value)
+	if value == "" || (value[0] != '\'' && value[0] != '"') {
+		return "", 0, fmt.Errorf("unquoted predicate value")
+	}
+	end := strings.IndexByte(value[1:], value[0])
+	if end < 0 {
+		return "", 0, fmt.Errorf("unterminated predicate value")
+	}
+	closing := strings.TrimLeft(value[end+2:], " ")
+	if !strings.HasPrefix(closing, "]") {
+		return "", 0, fmt.Errorf("unterminated predicate")
+	}
+	return value[1 : end+1], offset + len(value) - len(closing) + 1, nil
+}
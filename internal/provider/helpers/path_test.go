@@ -0,0 +1,113 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeKey(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"ce0"}, "ce0"},
+		{[]string{"0/1"}, "0%2F1"},
+		{[]string{"a,b", "c d"}, "a%2Cb,c%20d"},
+		{[]string{"a+b"}, "a%2Bb"},
+		{[]string{"x=y"}, "x%3Dy"},
+	}
+	for _, tt := range tests {
+		if got := EncodeKey(tt.values...); got != tt.want {
+			t.Errorf("EncodeKey(%q) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestPathKeys(t *testing.T) {
+	tests := []struct {
+		format string
+		path   string
+		want   []string
+	}{
+		{"tailf-ncs:devices/device=%v", "tailf-ncs:devices/device=ce0", []string{"ce0"}},
+		{"tailf-ncs:devices/device=%v", "tailf-ncs:devices/device=a%2Fb%20c", []string{"a/b c"}},
+		{"tailf-ncs:devices/device=%v", "tailf-ncs:devices/device=a+b", []string{"a+b"}},
+		{"tailf-ncs:devices/device=%v/config/tailf-ned-cisco-ios:interface/GigabitEthernet=%v", "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", []string{"ce0", "0/1"}},
+		{"l3vpn:vpn/l3vpn=%v,%v", "l3vpn:vpn/l3vpn=a%2Cb,c", []string{"a,b", "c"}},
+		{"tailf-ncs:devices", "tailf-ncs:devices", []string{}},
+		{"tailf-ncs:devices/device=%v", "tailf-ncs:devices/device-group=g1", nil},
+		{"tailf-ncs:devices/device=%v/config", "tailf-ncs:devices/device=ce0", nil},
+		{"tailf-ncs:devices", "tailf-ncs:devices/device=ce0", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := PathKeys(tt.format, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PathKeys(%q, %q) = %#v, want %#v", tt.format, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestconfPath(t *testing.T) {
+	got, err := RestconfPath([][]string{{"tailf-ncs:devices"}, {"device", "ce0"}, {"config"}, {"tailf-ned-cisco-ios:interface"}, {"GigabitEthernet", "0/1"}})
+	if want := "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1"; err != nil || got != want {
+		t.Errorf("RestconfPath = %q, %v, want %q", got, err, want)
+	}
+	for _, segments := range [][][]string{{{}}, {{"tailf-ncs:devices"}, {""}}, {{"devices/device"}}} {
+		if _, err := RestconfPath(segments); err == nil {
+			t.Errorf("RestconfPath(%q): expected error", segments)
+		}
+	}
+}
+
+func TestDeviceConfigPath(t *testing.T) {
+	if got, want := DeviceConfigPath("ce/0", ""), "tailf-ncs:devices/device=ce%2F0/config"; got != want {
+		t.Errorf("DeviceConfigPath = %q, want %q", got, want)
+	}
+	if got, want := DeviceConfigPath("ce0", "tailf-ned-cisco-ios:hostname"), "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:hostname"; got != want {
+		t.Errorf("DeviceConfigPath = %q, want %q", got, want)
+	}
+}
+
+func TestXPathToRestconf(t *testing.T) {
+	tests := []struct {
+		xpath string
+		want  string
+	}{
+		{"/ncs:devices/device[name='ce0']/config", "tailf-ncs:devices/device=ce0/config"},
+		{"/ncs:devices/ncs:device[ncs:name='ce0']", "tailf-ncs:devices/device=ce0"},
+		{"/ncs:devices/device[name=\"ce0\"]/config/tailf-ned-cisco-ios:interface/GigabitEthernet[name='0/1']", "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1"},
+		{"/l3vpn:vpn/l3vpn[name='a'][ id = 'b]c' ]", "l3vpn:vpn/l3vpn=a,b%5Dc"},
+		{"tailf-ncs:services", "tailf-ncs:services"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.xpath, func(t *testing.T) {
+			got, err := XPathToRestconf(tt.xpath)
+			if err != nil || got != tt.want {
+				t.Errorf("XPathToRestconf(%q) = %q, %v, want %q", tt.xpath, got, err, tt.want)
+			}
+		})
+	}
+	for _, xpath := range []string{"", "/", "/ncs:devices//device", "/ncs:devices/device[1]", "/ncs:devices/device[name=ce0]", "/ncs:devices/device[name='ce0'", "/ncs:devices/device[name='ce0']x"} {
+		if got, err := XPathToRestconf(xpath); err == nil {
+			t.Errorf("XPathToRestconf(%q) = %q, expected error", xpath, got)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive leaves in logged and recorded RESTCONF bodies
+const Redacted = "REDACTED"
+
+// SensitiveLeaves matches the names of leaves whose values are redacted by default, like the remote-password of an
+// authgroup
+var SensitiveLeaves = regexp.MustCompile(`(?i)(password|passphrase|secret|private-key)`)
+
+// SensitiveLeavesWith extends SensitiveLeaves by additional leaf names, which may include a module prefix.
+func SensitiveLeavesWith(names []string) *regexp.Regexp {
+	if len(names) == 0 {
+		return SensitiveLeaves
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return regexp.MustCompile(SensitiveLeaves.String() + `|^(?:[^:]+:)?(?:` + strings.Join(quoted, "|") + `)$`)
+}
+
+// RedactBody replaces the values of leaves matching sensitive in a JSON body. JSON bodies are normalized, as the
+// member order of bodies built from Terraform maps is not stable. Other bodies are returned unchanged.
+func RedactBody(body []byte, sensitive *regexp.Regexp) string {
+	if len(body) == 0 {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return string(body)
+	}
+	normalized, err := json.Marshal(redactValue(v, sensitive))
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
+
+func redactValue(v interface{}, sensitive *regexp.Regexp) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			switch e.(type) {
+			case map[string]interface{}, []interface{}:
+				t[k] = redactValue(e, sensitive)
+			default:
+				if e != nil && sensitive.MatchString(k) {
+					t[k] = Redacted
+				}
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactValue(e, sensitive)
+		}
+	}
+	return v
+}
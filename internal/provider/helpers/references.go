@@ -0,0 +1,65 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// CheckReferences verifies that the NSO entries referenced by a leafref attribute exist. The target is a RESTCONF
+// path where the last "%v" is replaced by the value, preceding arguments are passed in args. Leaf-lists are checked
+// element by element. Missing entries result in a warning as they might be created by another resource of the same
+// apply.
+func CheckReferences(ctx context.Context, client *restconf.Client, p path.Path, value attr.Value, target string, args ...interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client == nil || value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+	var elements []attr.Value
+	switch v := value.(type) {
+	case basetypes.SetValue:
+		elements = v.Elements()
+	case basetypes.ListValue:
+		elements = v.Elements()
+	default:
+		elements = []attr.Value{value}
+	}
+	for _, element := range elements {
+		if element.IsNull() || element.IsUnknown() {
+			continue
+		}
+		a := append(append([]interface{}{}, args...), EncodeKey(valueString(element)))
+		targetPath := fmt.Sprintf(target, a...)
+		tflog.Debug(ctx, fmt.Sprintf("%s: Checking reference", targetPath))
+		res, err := client.GetData(targetPath, restconf.Query("content", "config"), restconf.Query("depth", "1"))
+		if res.StatusCode == 404 {
+			diags.AddAttributeWarning(p, "Referenced entry not found", fmt.Sprintf("The entry '%s' referenced by this attribute does not exist. Applying the configuration will fail unless the entry is created by another resource of the same apply.", targetPath))
+		} else if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("%s: Failed to check reference: %s", targetPath, err))
+		}
+	}
+	return diags
+}
@@ -0,0 +1,53 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// UpgradeListItems converts the list items of a raw nso_restconf or nso_device_config state. Before 0.2.0 each list
+// item was an object with an attributes map holding the leafs, since then the item is the map of the leafs itself.
+// States of 0.2.0 and later are returned unchanged.
+func UpgradeListItems(state []byte) ([]byte, error) {
+	s := string(state)
+	var err error
+	gjson.Get(s, "lists").ForEach(func(i, list gjson.Result) bool {
+		list.Get("items").ForEach(func(ii, item gjson.Result) bool {
+			// A leaf called "attributes" of a current item is a string, never an object or null
+			attributes := item.Get("attributes")
+			if !item.IsObject() || !attributes.Exists() || !(attributes.IsObject() || attributes.Type == gjson.Null) {
+				return true
+			}
+			raw := attributes.Raw
+			if attributes.Type == gjson.Null {
+				raw = "{}"
+			}
+			s, err = sjson.SetRaw(s, fmt.Sprintf("lists.%d.items.%d", i.Int(), ii.Int()), raw)
+			return err == nil
+		})
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
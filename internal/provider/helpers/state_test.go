@@ -0,0 +1,57 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"testing"
+)
+
+func TestUpgradeListItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  string
+	}{
+		{
+			name:  "attributes map",
+			state: `{"path":"a","lists":[{"name":"rule","key":"seq","items":[{"attributes":{"seq":"10","rule":"permit ip any"}},{"attributes":null}]}]}`,
+			want:  `{"path":"a","lists":[{"name":"rule","key":"seq","items":[{"seq":"10","rule":"permit ip any"},{}]}]}`,
+		},
+		{
+			name:  "current",
+			state: `{"path":"a","lists":[{"name":"rule","key":"seq","items":[{"seq":"10","attributes":"x"}],"values":null}]}`,
+			want:  `{"path":"a","lists":[{"name":"rule","key":"seq","items":[{"seq":"10","attributes":"x"}],"values":null}]}`,
+		},
+		{
+			name:  "no lists",
+			state: `{"path":"a","lists":null}`,
+			want:  `{"path":"a","lists":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UpgradeListItems([]byte(tt.state))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("UpgradeListItems() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package helpers
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -75,3 +76,45 @@ func GetInt64List(result []gjson.Result) types.List {
 	}
 	return types.ListValueMust(types.Int64Type, v)
 }
+
+func GetFloat64List(result []gjson.Result) types.List {
+	v := make([]attr.Value, len(result))
+	for r := range result {
+		v[r] = types.Float64Value(result[r].Float())
+	}
+	return types.ListValueMust(types.Float64Type, v)
+}
+
+func GetStringSet(result []gjson.Result) types.Set {
+	v := make([]attr.Value, len(result))
+	for r := range result {
+		v[r] = types.StringValue(result[r].String())
+	}
+	return types.SetValueMust(types.StringType, v)
+}
+
+func GetInt64Set(result []gjson.Result) types.Set {
+	v := make([]attr.Value, len(result))
+	for r := range result {
+		v[r] = types.Int64Value(result[r].Int())
+	}
+	return types.SetValueMust(types.Int64Type, v)
+}
+
+func GetFloat64Set(result []gjson.Result) types.Set {
+	v := make([]attr.Value, len(result))
+	for r := range result {
+		v[r] = types.Float64Value(result[r].Float())
+	}
+	return types.SetValueMust(types.Float64Type, v)
+}
+
+// SortedKeys returns the keys of a map in sorted order, used to render map-keyed lists deterministically
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
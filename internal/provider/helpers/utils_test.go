@@ -0,0 +1,84 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+func TestLastElement(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"tailf-ncs:devices", "tailf-ncs:devices"},
+		{"tailf-ncs:devices/device=ce0", "tailf-ncs:device"},
+		{"tailf-ncs:devices/device=ce0/config", "tailf-ncs:config"},
+		{"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", "tailf-ned-cisco-ios:GigabitEthernet"},
+		{"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:hostname", "tailf-ned-cisco-ios:hostname"},
+		{"tailf-ncs:services/l3vpn:vpn/l3vpn=a,b", "l3vpn:l3vpn"},
+		{"tailf-ncs:customers/customer=a=b", "tailf-ncs:customer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := LastElement(tt.path); got != tt.want {
+				t.Errorf("LastElement(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	if !Contains([]string{"a", "b"}, "b") {
+		t.Error("expected slice to contain b")
+	}
+	if Contains([]string{"a", "b"}, "c") || Contains(nil, "a") {
+		t.Error("expected slice not to contain value")
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	got := SortedKeys(map[string]int{"b": 1, "c": 2, "a": 3})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+	if got := SortedKeys(map[string]int{}); len(got) != 0 {
+		t.Errorf("SortedKeys() = %v, want empty slice", got)
+	}
+}
+
+func TestGetCollections(t *testing.T) {
+	res := gjson.Parse(`["1","2"]`).Array()
+	if got := GetStringList(res); !got.Equal(types.ListValueMust(types.StringType, GetValueSlice(res))) {
+		t.Errorf("GetStringList() = %v", got)
+	}
+	if got := GetInt64List(res); !got.Equal(types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1), types.Int64Value(2)})) {
+		t.Errorf("GetInt64List() = %v", got)
+	}
+	if got := GetFloat64Set(gjson.Parse(`[1.5]`).Array()); !got.Equal(types.SetValueMust(types.Float64Type, []attr.Value{types.Float64Value(1.5)})) {
+		t.Errorf("GetFloat64Set() = %v", got)
+	}
+	if got := GetStringSet(nil); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("GetStringSet() = %v, want empty set", got)
+	}
+}
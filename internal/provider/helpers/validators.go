@@ -0,0 +1,133 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// AlsoRequiresValueValidator validates that an attribute is only configured if the attribute
+// referenced by Expression is configured with one of Values, which corresponds to a YANG
+// when statement like "../a = 'x' or ../a = 'y'".
+type AlsoRequiresValueValidator struct {
+	Expression path.Expression
+	Values     []string
+}
+
+var (
+	_ validator.String  = AlsoRequiresValueValidator{}
+	_ validator.Int64   = AlsoRequiresValueValidator{}
+	_ validator.Float64 = AlsoRequiresValueValidator{}
+	_ validator.Bool    = AlsoRequiresValueValidator{}
+	_ validator.List    = AlsoRequiresValueValidator{}
+)
+
+func AlsoRequiresValue(expression path.Expression, values ...string) AlsoRequiresValueValidator {
+	return AlsoRequiresValueValidator{Expression: expression, Values: values}
+}
+
+func (v AlsoRequiresValueValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Attribute can only be configured if %q is one of: %s", v.Expression, strings.Join(v.Values, ", "))
+}
+
+func (v AlsoRequiresValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v AlsoRequiresValueValidator) validate(ctx context.Context, config tfsdk.Config, p path.Path, expression path.Expression, value attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+	paths, d := config.PathMatches(ctx, expression.Merge(v.Expression))
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(paths) == 0 {
+		diags.AddAttributeError(p, "Invalid Attribute Combination",
+			fmt.Sprintf("Attribute %q can only be configured if %q is one of: %s", p, v.Expression, strings.Join(v.Values, ", ")))
+		return diags
+	}
+	for _, mp := range paths {
+		var target attr.Value
+		diags.Append(config.GetAttribute(ctx, mp, &target)...)
+		if diags.HasError() {
+			return diags
+		}
+		if target.IsUnknown() {
+			continue
+		}
+		if !target.IsNull() {
+			s := valueString(target)
+			for _, value := range v.Values {
+				if s == value {
+					return diags
+				}
+			}
+		}
+		diags.AddAttributeError(p, "Invalid Attribute Combination",
+			fmt.Sprintf("Attribute %q can only be configured if %q is one of: %s", p, mp, strings.Join(v.Values, ", ")))
+	}
+	return diags
+}
+
+func (v AlsoRequiresValueValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, req.Path, req.PathExpression, req.ConfigValue)...)
+}
+
+func (v AlsoRequiresValueValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, req.Path, req.PathExpression, req.ConfigValue)...)
+}
+
+func (v AlsoRequiresValueValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, req.Path, req.PathExpression, req.ConfigValue)...)
+}
+
+func (v AlsoRequiresValueValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, req.Path, req.PathExpression, req.ConfigValue)...)
+}
+
+func (v AlsoRequiresValueValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config, req.Path, req.PathExpression, req.ConfigValue)...)
+}
+
+// Get the string representation of a value as used in RESTCONF payloads
+func valueString(v attr.Value) string {
+	switch v := v.(type) {
+	case basetypes.StringValue:
+		return v.ValueString()
+	case basetypes.Int64Value:
+		return strconv.FormatInt(v.ValueInt64(), 10)
+	case basetypes.Float64Value:
+		return strconv.FormatFloat(v.ValueFloat64(), 'f', -1, 64)
+	case basetypes.BoolValue:
+		return strconv.FormatBool(v.ValueBool())
+	}
+	return v.String()
+}
@@ -0,0 +1,136 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ list.ListResource = &DeviceConfigListResource{}
+var _ list.ListResourceWithConfigure = &DeviceConfigListResource{}
+
+func NewDeviceConfigListResource() list.ListResource {
+	return &DeviceConfigListResource{}
+}
+
+type DeviceConfigListResource struct {
+	clients map[string]*restconf.Client
+}
+
+func (r *DeviceConfigListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_config"
+}
+
+func (r *DeviceConfigListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Discovers the config of an NSO device at a RESTCONF path. If the path refers to a YANG list, every list entry is returned as a separate resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "An NSO device name.",
+				Required:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "A RESTCONF path.",
+				Optional:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "YANG list key attribute, required if the path refers to a YANG list. In case of multiple keys, those should be separated by a comma (`,`).",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *DeviceConfigListResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (r *DeviceConfigListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config DeviceConfigs
+
+	// Read config
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if _, ok := r.clients[config.Instance.ValueString()]; !ok {
+		diags.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", config.Instance.ValueString()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning List", config.getPath()))
+
+	res, err := r.clients[config.Instance.ValueString()].GetData(config.getPath(), restconf.Query("content", "config"))
+	if res.StatusCode == 404 {
+		stream.Results = list.NoListResults
+		return
+	} else if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to retrieve object, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if config.isList(res.Res) && config.Key.ValueString() == "" {
+		diags.AddAttributeError(path.Root("key"), "Missing list key", fmt.Sprintf("Path '%s' refers to a YANG list, the list key attribute is required to discover its entries.", config.Path.ValueString()))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	items := config.fromBody(ctx, res.Res)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: List finished successfully, %d entries", config.getPath(), len(items)))
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, item := range items {
+			result := req.NewListResult(ctx)
+			result.DisplayName = item.Device.ValueString()
+			if item.Path.ValueString() != "" {
+				result.DisplayName += " " + item.Path.ValueString()
+			}
+			result.Diagnostics.Append(result.Identity.Set(ctx, item.getIdentity())...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &item)...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeviceConfigListResource(t *testing.T) {
+	s := nsomock.NewServer()
+	defer s.Close()
+	s.SetConfig("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":[{"name":"ce0","config":{
+		"tailf-ned-cisco-ios:hostname":"R1",
+		"tailf-ned-cisco-ios:interface":{"GigabitEthernet":[{"name":"0/1","description":"uplink"},{"name":"0/2","shutdown":[null]}]}
+	}}]}`)
+
+	device := tftypes.NewValue(tftypes.String, "ce0")
+	results := testListResource(t, s, "nso_device_config", map[string]tftypes.Value{
+		"device": device,
+		"path":   tftypes.NewValue(tftypes.String, "tailf-ned-cisco-ios:interface/GigabitEthernet"),
+		"key":    tftypes.NewValue(tftypes.String, "name"),
+	})
+	if len(results) != 2 {
+		t.Fatalf("want 2 results, got %d", len(results))
+	}
+	for i, want := range []string{"tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", "tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F2"} {
+		if got := testString(t, results[i].Identity["path"]); got != want {
+			t.Errorf("identity path = %q, want %q", got, want)
+		}
+		if got := testString(t, results[i].Resource["path"]); got != want {
+			t.Errorf("path = %q, want %q", got, want)
+		}
+		if got := testString(t, results[i].Identity["device"]); got != "ce0" {
+			t.Errorf("identity device = %q, want %q", got, "ce0")
+		}
+		if results[i].DisplayName != "ce0 "+want {
+			t.Errorf("display name = %q, want %q", results[i].DisplayName, "ce0 "+want)
+		}
+	}
+	var attributes map[string]tftypes.Value
+	if err := results[0].Resource["attributes"].As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	if got := testString(t, attributes["description"]); got != "uplink" {
+		t.Errorf("description = %q, want %q", got, "uplink")
+	}
+
+	// the whole device config is a single resource
+	results = testListResource(t, s, "nso_device_config", map[string]tftypes.Value{"device": device})
+	if len(results) != 1 || testString(t, results[0].Identity["path"]) != "<null>" {
+		t.Fatalf("device config: unexpected results %+v", results)
+	}
+	if err := results[0].Resource["attributes"].As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	if got := testString(t, attributes["tailf-ned-cisco-ios:hostname"]); got != "R1" {
+		t.Errorf("hostname = %q, want %q", got, "R1")
+	}
+
+	// nothing to list for a device without config at the path
+	results = testListResource(t, s, "nso_device_config", map[string]tftypes.Value{
+		"device": tftypes.NewValue(tftypes.String, "ce1"),
+	})
+	if len(results) != 0 {
+		t.Errorf("unknown device: want no results, got %+v", results)
+	}
+}
+
+func TestDeviceConfigListResourceMissingKey(t *testing.T) {
+	s := nsomock.NewServer()
+	defer s.Close()
+	s.SetConfig("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":[{"name":"ce0","config":{"tailf-ned-cisco-ios:interface":{"GigabitEthernet":[{"name":"0/1"}]}}}]}`)
+
+	results, errs := testListResourceErrors(t, s, "nso_device_config", map[string]tftypes.Value{
+		"device": tftypes.NewValue(tftypes.String, "ce0"),
+		"path":   tftypes.NewValue(tftypes.String, "tailf-ned-cisco-ios:interface/GigabitEthernet"),
+	})
+	if len(results) != 0 || len(errs) != 1 || !strings.HasPrefix(errs[0], "Missing list key") {
+		t.Errorf("want missing list key error, got results %+v, errors %v", results, errs)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeviceGroupListResource(t *testing.T) {
+	s := nsomock.NewServer()
+	defer s.Close()
+	s.SetConfig("tailf-ncs:devices/device-group=g1", `{"tailf-ncs:device-group":[{"name":"g1","device-name":["ce0","ce/1"]}]}`)
+
+	results := testListResource(t, s, "nso_device_group", nil)
+	if len(results) != 1 || testString(t, results[0].Identity["name"]) != "g1" {
+		t.Fatalf("nso_device_group: unexpected results %+v", results)
+	}
+	var deviceNames []tftypes.Value
+	if err := results[0].Resource["device_names"].As(&deviceNames); err != nil || len(deviceNames) != 2 {
+		t.Errorf("nso_device_group: device_names = %v, want 2 devices", results[0].Resource["device_names"])
+	}
+}
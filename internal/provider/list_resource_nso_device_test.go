@@ -0,0 +1,78 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeviceListResource(t *testing.T) {
+	s := nsomock.NewServer()
+	defer s.Close()
+	s.SetConfig("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":[{"name":"ce0","address":"10.1.1.1","authgroup":"default"}]}`)
+	s.SetConfig("tailf-ncs:devices/device=ce%2F1", `{"tailf-ncs:device":[{"name":"ce/1","address":"10.1.1.2"}]}`)
+
+	results := testListResource(t, s, "nso_device", nil)
+	if len(results) != 2 {
+		t.Fatalf("nso_device: want 2 results, got %d", len(results))
+	}
+	for i, want := range []struct{ name, id, address string }{
+		{"ce0", "tailf-ncs:devices/device=ce0", "10.1.1.1"},
+		{"ce/1", "tailf-ncs:devices/device=ce%2F1", "10.1.1.2"},
+	} {
+		if results[i].DisplayName != want.name {
+			t.Errorf("nso_device: display name = %q, want %q", results[i].DisplayName, want.name)
+		}
+		if got := testString(t, results[i].Identity["name"]); got != want.name {
+			t.Errorf("nso_device: identity name = %q, want %q", got, want.name)
+		}
+		if got := testString(t, results[i].Resource["id"]); got != want.id {
+			t.Errorf("nso_device: id = %q, want %q", got, want.id)
+		}
+		if got := testString(t, results[i].Resource["address"]); got != want.address {
+			t.Errorf("nso_device: address = %q, want %q", got, want.address)
+		}
+	}
+
+	// only the entry of the filter and the modeled leaves are requested
+	var requests []string
+	handler := s.Config.Handler
+	s.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/device") {
+			requests = append(requests, r.URL.EscapedPath()+"?"+r.URL.Query().Get("fields"))
+		}
+		handler.ServeHTTP(w, r)
+	})
+	results = testListResource(t, s, "nso_device", map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "ce/1")})
+	if len(results) != 1 || results[0].DisplayName != "ce/1" {
+		t.Errorf("nso_device: filter by name, got %+v", results)
+	}
+	if len(requests) != 1 || !strings.HasSuffix(requests[0], "/tailf-ncs:devices/device=ce%2F1?name;address;port;connect-timeout;read-timeout;write-timeout;authgroup;state/admin-state;device-type/netconf/ned-id;device-type/cli/ned-id;device-type/generic/ned-id") {
+		t.Errorf("nso_device: filter by name, requests %v", requests)
+	}
+
+	results = testListResource(t, s, "nso_device", map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "ce2")})
+	if len(results) != 0 {
+		t.Errorf("nso_device: filter by missing name, got %+v", results)
+	}
+}
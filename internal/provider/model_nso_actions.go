@@ -0,0 +1,134 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const packagesReloadPath = "tailf-ncs:packages/reload"
+
+type DeviceSync struct {
+	Instance types.String `tfsdk:"instance"`
+	Device   types.String `tfsdk:"device"`
+	DryRun   types.Bool   `tfsdk:"dry_run"`
+}
+
+type DeviceCompareConfig struct {
+	Instance   types.String `tfsdk:"instance"`
+	Device     types.String `tfsdk:"device"`
+	Outformat  types.String `tfsdk:"outformat"`
+	FailOnDiff types.Bool   `tfsdk:"fail_on_diff"`
+}
+
+type DeviceConnect struct {
+	Instance types.String `tfsdk:"instance"`
+	Device   types.String `tfsdk:"device"`
+}
+
+type ServiceRedeploy struct {
+	Instance  types.String `tfsdk:"instance"`
+	Path      types.String `tfsdk:"path"`
+	Reconcile types.Bool   `tfsdk:"reconcile"`
+	DryRun    types.Bool   `tfsdk:"dry_run"`
+}
+
+type PackagesReload struct {
+	Instance types.String `tfsdk:"instance"`
+	Force    types.Bool   `tfsdk:"force"`
+}
+
+func deviceActionPath(device types.String, action string) string {
+	return DeviceConfig{Device: device}.getDeviceActionPath(action)
+}
+
+// A dry run of sync-to and sync-from returns the changes in CLI format
+func (data DeviceSync) toBody() string {
+	if data.DryRun.ValueBool() {
+		return `{"input":{"dry-run":{"outformat":"cli"}}}`
+	}
+	return `{"input":{}}`
+}
+
+func (data DeviceCompareConfig) toBody() string {
+	if data.Outformat.IsNull() {
+		return `{"input":{}}`
+	}
+	body, _ := sjson.Set(`{"input":{}}`, "input.outformat", data.Outformat.ValueString())
+	return body
+}
+
+func (data ServiceRedeploy) toBody() string {
+	body := Service{RedeployReconcile: data.Reconcile}.getRedeployBody()
+	if data.DryRun.ValueBool() {
+		body, _ = sjson.SetRaw(body, "input.dry-run", `{"outformat":"cli"}`)
+	}
+	return body
+}
+
+func (data PackagesReload) toBody() string {
+	if data.Force.ValueBool() {
+		return `{"input":{"force":[null]}}`
+	}
+	return `{"input":{}}`
+}
+
+// deviceActionResult returns the result of sync-from, sync-to and connect, which report failures with result false
+// and a reason in info
+func deviceActionResult(res gjson.Result) (bool, string) {
+	output := actionOutput(res)
+	return output.Get("result").Bool(), output.Get("info").String()
+}
+
+// dryRunOutput returns the changes of a dry run, which are the device changes for device actions and the changes
+// of the local node for services
+func dryRunOutput(res gjson.Result) string {
+	output := actionOutput(res)
+	if cli := output.Get("cli"); cli.Exists() && !cli.IsObject() {
+		return cli.String()
+	}
+	return output.Get("cli.local-node.data").String()
+}
+
+// compareConfigDiff returns the differences between NSO and the device, which are empty if they are in sync
+func compareConfigDiff(res gjson.Result) string {
+	output := actionOutput(res)
+	for _, p := range []string{"diff", "cli", "xml", "json", "native.device.0.data"} {
+		if v := output.Get(p); v.Exists() && !v.IsObject() {
+			return v.String()
+		}
+	}
+	return ""
+}
+
+// packagesReloadFailures returns the packages which failed to load including the reason
+func packagesReloadFailures(res gjson.Result) []string {
+	failures := make([]string, 0)
+	actionOutput(res).Get("reload-result").ForEach(func(_, v gjson.Result) bool {
+		if !v.Get("result").Bool() {
+			failures = append(failures, strings.TrimSpace(fmt.Sprintf("%s: %s", v.Get("package").String(), v.Get("info").String())))
+		}
+		return true
+	})
+	return failures
+}
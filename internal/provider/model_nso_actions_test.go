@@ -0,0 +1,99 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+func TestActionModelBodies(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"sync", DeviceSync{DryRun: types.BoolNull()}.toBody(), `{"input":{}}`},
+		{"sync dry run", DeviceSync{DryRun: types.BoolValue(true)}.toBody(), `{"input":{"dry-run":{"outformat":"cli"}}}`},
+		{"compare config", DeviceCompareConfig{Outformat: types.StringNull()}.toBody(), `{"input":{}}`},
+		{"compare config outformat", DeviceCompareConfig{Outformat: types.StringValue("xml")}.toBody(), `{"input":{"outformat":"xml"}}`},
+		{"redeploy", ServiceRedeploy{Reconcile: types.BoolNull(), DryRun: types.BoolNull()}.toBody(), `{"input":{}}`},
+		{"redeploy reconcile dry run", ServiceRedeploy{Reconcile: types.BoolValue(true), DryRun: types.BoolValue(true)}.toBody(), `{"input":{"reconcile":{},"dry-run":{"outformat":"cli"}}}`},
+		{"packages reload", PackagesReload{Force: types.BoolValue(false)}.toBody(), `{"input":{}}`},
+		{"packages reload force", PackagesReload{Force: types.BoolValue(true)}.toBody(), `{"input":{"force":[null]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONEqual(t, tt.want, tt.body)
+		})
+	}
+	if got, want := deviceActionPath(types.StringValue("ce0"), "connect"), "tailf-ncs:devices/device=ce0/connect"; got != want {
+		t.Errorf("deviceActionPath() = %q, want %q", got, want)
+	}
+}
+
+func TestActionModelOutputs(t *testing.T) {
+	result, info := deviceActionResult(gjson.Parse(`{"tailf-ncs:output":{"result":false,"info":"Failed to connect to device ce0: connection refused"}}`))
+	if result || info != "Failed to connect to device ce0: connection refused" {
+		t.Errorf("deviceActionResult() = %v, %q", result, info)
+	}
+	if result, _ := deviceActionResult(gjson.Parse(`{"tailf-ncs:output":{"result":true}}`)); !result {
+		t.Error("deviceActionResult() = false, want true")
+	}
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"tailf-ncs:output":{"cli":" hostname R1"}}`, " hostname R1"},
+		{`{"l3vpn:output":{"cli":{"local-node":{"data":" devices {\n }"}}}}`, " devices {\n }"},
+		{`{"tailf-ncs:output":{}}`, ""},
+	}
+	for _, tt := range tests {
+		if got := dryRunOutput(gjson.Parse(tt.body)); got != tt.want {
+			t.Errorf("dryRunOutput(%s) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+
+	tests = []struct {
+		body string
+		want string
+	}{
+		{`{"tailf-ncs:output":{"diff":" hostname R1\n-hostname R2"}}`, " hostname R1\n-hostname R2"},
+		{`{"tailf-ncs:output":{"xml":"<config/>"}}`, "<config/>"},
+		{`{"tailf-ncs:output":{}}`, ""},
+		{`{}`, ""},
+	}
+	for _, tt := range tests {
+		if got := compareConfigDiff(gjson.Parse(tt.body)); got != tt.want {
+			t.Errorf("compareConfigDiff(%s) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+
+	failures := packagesReloadFailures(gjson.Parse(`{"tailf-ncs:output":{"reload-result":[
+		{"package":"cisco-ios-cli-6.90","result":true},
+		{"package":"l3vpn","result":false,"info":"l3vpn.yang:12: error: syntax error"},
+		{"package":"broken","result":false}
+	]}}`))
+	if want := []string{"l3vpn: l3vpn.yang:12: error: syntax error", "broken:"}; !reflect.DeepEqual(failures, want) {
+		t.Errorf("packagesReloadFailures() = %q, want %q", failures, want)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+type CommitQueue struct {
+	Instance   types.String           `tfsdk:"instance"`
+	Id         types.String           `tfsdk:"id"`
+	Status     types.String           `tfsdk:"status"`
+	QueueItems []CommitQueueQueueItem `tfsdk:"queue_items"`
+}
+
+type CommitQueueQueueItem struct {
+	Id               types.Int64  `tfsdk:"id"`
+	Tag              types.String `tfsdk:"tag"`
+	Age              types.Int64  `tfsdk:"age"`
+	Status           types.String `tfsdk:"status"`
+	Devices          types.List   `tfsdk:"devices"`
+	CompletedDevices types.List   `tfsdk:"completed_devices"`
+	FailedDevices    types.List   `tfsdk:"failed_devices"`
+}
+
+func (data CommitQueue) getPath() string {
+	return helpers.CommitQueueItemPath
+}
+
+func (data *CommitQueue) fromBody(ctx context.Context, res gjson.Result) {
+	data.QueueItems = make([]CommitQueueQueueItem, 0)
+	res.Get("*:queue-item").ForEach(func(_, v gjson.Result) bool {
+		item := CommitQueueQueueItem{
+			Id:     types.Int64Value(v.Get("id").Int()),
+			Tag:    types.StringValue(v.Get("tag").String()),
+			Age:    types.Int64Value(v.Get("age").Int()),
+			Status: types.StringValue(v.Get("status").String()),
+		}
+		if !data.Status.IsNull() && !data.Status.Equal(item.Status) {
+			return true
+		}
+		item.Devices, _ = types.ListValueFrom(ctx, types.StringType, helpers.CommitQueueDevices(v.Get("devices")))
+		item.CompletedDevices, _ = types.ListValueFrom(ctx, types.StringType, helpers.CommitQueueDevices(v.Get("completed")))
+		item.FailedDevices, _ = types.ListValueFrom(ctx, types.StringType, helpers.CommitQueueFailedDevices(v))
+		data.QueueItems = append(data.QueueItems, item)
+		return true
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+func TestCommitQueueModelFromBody(t *testing.T) {
+	body := gjson.Parse(`{"tailf-ncs:queue-item":[
+		{"id":1,"tag":"t1","age":10,"status":"executing","devices":["ce0","ce1"],"completed":["ce0"]},
+		{"id":2,"age":5,"status":"failed","devices":["ce2"],"failed":[{"name":"ce2","reason":"connection refused"}]}
+	]}`)
+	tests := []struct {
+		status string
+		want   []int64
+	}{
+		{"", []int64{1, 2}},
+		{"failed", []int64{2}},
+		{"completed", []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			data := CommitQueue{Status: types.StringNull()}
+			if tt.status != "" {
+				data.Status = types.StringValue(tt.status)
+			}
+			data.fromBody(context.Background(), body)
+			if len(data.QueueItems) != len(tt.want) {
+				t.Fatalf("got %d queue items, want %d", len(data.QueueItems), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got := data.QueueItems[i].Id.ValueInt64(); got != id {
+					t.Errorf("queue item %d has id %d, want %d", i, got, id)
+				}
+			}
+		})
+	}
+
+	data := CommitQueue{Status: types.StringNull()}
+	data.fromBody(context.Background(), body)
+	if want := testStringList("ce0", "ce1"); !data.QueueItems[0].Devices.Equal(want) {
+		t.Errorf("devices = %v, want %v", data.QueueItems[0].Devices, want)
+	}
+	if want := testStringList("ce0"); !data.QueueItems[0].CompletedDevices.Equal(want) {
+		t.Errorf("completed devices = %v, want %v", data.QueueItems[0].CompletedDevices, want)
+	}
+	if want := testStringList("ce2 (connection refused)"); !data.QueueItems[1].FailedDevices.Equal(want) {
+		t.Errorf("failed devices = %v, want %v", data.QueueItems[1].FailedDevices, want)
+	}
+}
@@ -22,7 +22,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"regexp"
 	"strconv"
 
@@ -33,37 +32,74 @@ import (
 )
 
 type Device struct {
-	Instance     types.String `tfsdk:"instance"`
-	Id           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Address      types.String `tfsdk:"address"`
-	Port         types.Int64  `tfsdk:"port"`
-	Authgroup    types.String `tfsdk:"authgroup"`
-	AdminState   types.String `tfsdk:"admin_state"`
-	NetconfNetId types.String `tfsdk:"netconf_net_id"`
-	CliNedId     types.String `tfsdk:"cli_ned_id"`
-	GenericNedId types.String `tfsdk:"generic_ned_id"`
+	Instance       types.String `tfsdk:"instance"`
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Address        types.String `tfsdk:"address"`
+	Port           types.Int64  `tfsdk:"port"`
+	ConnectTimeout types.Int64  `tfsdk:"connect_timeout"`
+	ReadTimeout    types.Int64  `tfsdk:"read_timeout"`
+	WriteTimeout   types.Int64  `tfsdk:"write_timeout"`
+	Authgroup      types.String `tfsdk:"authgroup"`
+	AdminState     types.String `tfsdk:"admin_state"`
+	NetconfNetId   types.String `tfsdk:"netconf_net_id"`
+	CliNedId       types.String `tfsdk:"cli_ned_id"`
+	GenericNedId   types.String `tfsdk:"generic_ned_id"`
 }
 
 type DeviceData struct {
-	Instance     types.String `tfsdk:"instance"`
-	Id           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Address      types.String `tfsdk:"address"`
-	Port         types.Int64  `tfsdk:"port"`
-	Authgroup    types.String `tfsdk:"authgroup"`
-	AdminState   types.String `tfsdk:"admin_state"`
-	NetconfNetId types.String `tfsdk:"netconf_net_id"`
-	CliNedId     types.String `tfsdk:"cli_ned_id"`
-	GenericNedId types.String `tfsdk:"generic_ned_id"`
+	Instance       types.String `tfsdk:"instance"`
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Address        types.String `tfsdk:"address"`
+	Port           types.Int64  `tfsdk:"port"`
+	ConnectTimeout types.Int64  `tfsdk:"connect_timeout"`
+	ReadTimeout    types.Int64  `tfsdk:"read_timeout"`
+	WriteTimeout   types.Int64  `tfsdk:"write_timeout"`
+	Authgroup      types.String `tfsdk:"authgroup"`
+	AdminState     types.String `tfsdk:"admin_state"`
+	NetconfNetId   types.String `tfsdk:"netconf_net_id"`
+	CliNedId       types.String `tfsdk:"cli_ned_id"`
+	GenericNedId   types.String `tfsdk:"generic_ned_id"`
+}
+
+type Devices struct {
+	Instance types.String  `tfsdk:"instance"`
+	Id       types.String  `tfsdk:"id"`
+	Name     types.String  `tfsdk:"name"`
+	Items    []DevicesItem `tfsdk:"devices"`
+}
+
+type DevicesItem struct {
+	Name           types.String `tfsdk:"name"`
+	Address        types.String `tfsdk:"address"`
+	Port           types.Int64  `tfsdk:"port"`
+	ConnectTimeout types.Int64  `tfsdk:"connect_timeout"`
+	ReadTimeout    types.Int64  `tfsdk:"read_timeout"`
+	WriteTimeout   types.Int64  `tfsdk:"write_timeout"`
+	Authgroup      types.String `tfsdk:"authgroup"`
+	AdminState     types.String `tfsdk:"admin_state"`
+	NetconfNetId   types.String `tfsdk:"netconf_net_id"`
+	CliNedId       types.String `tfsdk:"cli_ned_id"`
+	GenericNedId   types.String `tfsdk:"generic_ned_id"`
+}
+
+type DeviceIdentity struct {
+	Instance types.String `tfsdk:"instance"`
+	Name     types.String `tfsdk:"name"`
+}
+
+type DevicesList struct {
+	Instance types.String `tfsdk:"instance"`
+	Name     types.String `tfsdk:"name"`
 }
 
 func (data Device) getPath() string {
-	return fmt.Sprintf("tailf-ncs:devices/device=%v", url.QueryEscape(fmt.Sprintf("%v", data.Name.ValueString())))
+	return fmt.Sprintf("tailf-ncs:devices/device=%v", helpers.EncodeKey(fmt.Sprintf("%v", data.Name.ValueString())))
 }
 
 func (data DeviceData) getPath() string {
-	return fmt.Sprintf("tailf-ncs:devices/device=%v", url.QueryEscape(fmt.Sprintf("%v", data.Name.ValueString())))
+	return fmt.Sprintf("tailf-ncs:devices/device=%v", helpers.EncodeKey(fmt.Sprintf("%v", data.Name.ValueString())))
 }
 
 // if last path element has a key -> remove it
@@ -77,6 +113,26 @@ func (data Device) getPathShort() string {
 	return matches[1]
 }
 
+// The key values are taken from the RESTCONF path, as only the id is known after an import
+func (data Device) getIdentity() DeviceIdentity {
+	identity := DeviceIdentity{Instance: data.Instance}
+	keys := helpers.PathKeys("tailf-ncs:devices/device=%v", data.Id.ValueString())
+	if keys == nil {
+		identity.Name = data.Name
+		return identity
+	}
+	identity.Name = types.StringValue(keys[0])
+	return identity
+}
+
+// Resource model with the key values of an identity, as used by an import with the identity attribute
+func (data DeviceIdentity) toResource() Device {
+	return Device{
+		Instance: data.Instance,
+		Name:     data.Name,
+	}
+}
+
 func (data Device) toBody(ctx context.Context) string {
 	body := `{"` + helpers.LastElement(data.getPath()) + `":{}}`
 	if !data.Name.IsNull() && !data.Name.IsUnknown() {
@@ -88,6 +144,15 @@ func (data Device) toBody(ctx context.Context) string {
 	if !data.Port.IsNull() && !data.Port.IsUnknown() {
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"port", strconv.FormatInt(data.Port.ValueInt64(), 10))
 	}
+	if !data.ConnectTimeout.IsNull() && !data.ConnectTimeout.IsUnknown() {
+		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"connect-timeout", strconv.FormatInt(data.ConnectTimeout.ValueInt64(), 10))
+	}
+	if !data.ReadTimeout.IsNull() && !data.ReadTimeout.IsUnknown() {
+		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"read-timeout", strconv.FormatInt(data.ReadTimeout.ValueInt64(), 10))
+	}
+	if !data.WriteTimeout.IsNull() && !data.WriteTimeout.IsUnknown() {
+		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"write-timeout", strconv.FormatInt(data.WriteTimeout.ValueInt64(), 10))
+	}
 	if !data.Authgroup.IsNull() && !data.Authgroup.IsUnknown() {
 		body, _ = sjson.Set(body, helpers.LastElement(data.getPath())+"."+"authgroup", data.Authgroup.ValueString())
 	}
@@ -126,6 +191,21 @@ func (data *Device) updateFromBody(ctx context.Context, res gjson.Result) {
 	} else {
 		data.Port = types.Int64Null()
 	}
+	if value := res.Get(prefix + "connect-timeout"); value.Exists() && !data.ConnectTimeout.IsNull() {
+		data.ConnectTimeout = types.Int64Value(value.Int())
+	} else {
+		data.ConnectTimeout = types.Int64Null()
+	}
+	if value := res.Get(prefix + "read-timeout"); value.Exists() && !data.ReadTimeout.IsNull() {
+		data.ReadTimeout = types.Int64Value(value.Int())
+	} else {
+		data.ReadTimeout = types.Int64Null()
+	}
+	if value := res.Get(prefix + "write-timeout"); value.Exists() && !data.WriteTimeout.IsNull() {
+		data.WriteTimeout = types.Int64Value(value.Int())
+	} else {
+		data.WriteTimeout = types.Int64Null()
+	}
 	if value := res.Get(prefix + "authgroup"); value.Exists() && !data.Authgroup.IsNull() {
 		data.Authgroup = types.StringValue(value.String())
 	} else {
@@ -164,6 +244,15 @@ func (data *DeviceData) fromBody(ctx context.Context, res gjson.Result) {
 	if value := res.Get(prefix + "port"); value.Exists() {
 		data.Port = types.Int64Value(value.Int())
 	}
+	if value := res.Get(prefix + "connect-timeout"); value.Exists() {
+		data.ConnectTimeout = types.Int64Value(value.Int())
+	}
+	if value := res.Get(prefix + "read-timeout"); value.Exists() {
+		data.ReadTimeout = types.Int64Value(value.Int())
+	}
+	if value := res.Get(prefix + "write-timeout"); value.Exists() {
+		data.WriteTimeout = types.Int64Value(value.Int())
+	}
 	if value := res.Get(prefix + "authgroup"); value.Exists() {
 		data.Authgroup = types.StringValue(value.String())
 	}
@@ -181,6 +270,77 @@ func (data *DeviceData) fromBody(ctx context.Context, res gjson.Result) {
 	}
 }
 
+func (data Devices) getPath() string {
+	return "tailf-ncs:devices/device"
+}
+
+// Path of the list entry if all key filters are set, otherwise path of the list
+func (data Devices) getReadPath() string {
+	if data.Name.IsNull() {
+		return data.getPath()
+	}
+	return fmt.Sprintf("tailf-ncs:devices/device=%v", helpers.EncodeKey(fmt.Sprintf("%v", data.Name.ValueString())))
+}
+
+func (data *Devices) fromBody(ctx context.Context, res gjson.Result) {
+	data.Items = make([]DevicesItem, 0)
+	element := helpers.LastElement(data.getPath())
+	res.Get(element).ForEach(func(k, v gjson.Result) bool {
+		entry := DeviceData{}
+		entry.fromBody(ctx, gjson.Parse(`{"`+element+`":`+v.Raw+`}`))
+		item := DevicesItem{}
+		item.Name = types.StringValue(v.Get("name").String())
+		if !data.Name.IsNull() && !data.Name.Equal(item.Name) {
+			return true
+		}
+		item.Address = entry.Address
+		item.Port = entry.Port
+		item.ConnectTimeout = entry.ConnectTimeout
+		item.ReadTimeout = entry.ReadTimeout
+		item.WriteTimeout = entry.WriteTimeout
+		item.Authgroup = entry.Authgroup
+		item.AdminState = entry.AdminState
+		item.NetconfNetId = entry.NetconfNetId
+		item.CliNedId = entry.CliNedId
+		item.GenericNedId = entry.GenericNedId
+		data.Items = append(data.Items, item)
+		return true
+	})
+}
+
+func (data DevicesList) getList() Devices {
+	return Devices{
+		Instance: data.Instance,
+		Name:     data.Name,
+	}
+}
+
+// Resource models of all list entries, as read by the list resource
+func (data DevicesList) fromBody(ctx context.Context, res gjson.Result) []Device {
+	list := data.getList()
+	list.fromBody(ctx, res)
+	resources := make([]Device, 0, len(list.Items))
+	for _, item := range list.Items {
+		r := Device{
+			Instance:       data.Instance,
+			Name:           item.Name,
+			Address:        item.Address,
+			Port:           item.Port,
+			ConnectTimeout: item.ConnectTimeout,
+			ReadTimeout:    item.ReadTimeout,
+			WriteTimeout:   item.WriteTimeout,
+			Authgroup:      item.Authgroup,
+			AdminState:     item.AdminState,
+			NetconfNetId:   item.NetconfNetId,
+			CliNedId:       item.CliNedId,
+			GenericNedId:   item.GenericNedId,
+		}
+		r.Id = types.StringValue(r.getPath())
+		resources = append(resources, r)
+	}
+	return resources
+}
+
 func (data *Device) getDeletedListItems(ctx context.Context, state Device) []string {
 	deletedListItems := make([]string, 0)
 	return deletedListItems
@@ -199,6 +359,15 @@ func (data *Device) getDeletePaths(ctx context.Context) []string {
 	if !data.Port.IsNull() {
 		deletePaths = append(deletePaths, fmt.Sprintf("%v/port", data.getPath()))
 	}
+	if !data.ConnectTimeout.IsNull() {
+		deletePaths = append(deletePaths, fmt.Sprintf("%v/connect-timeout", data.getPath()))
+	}
+	if !data.ReadTimeout.IsNull() {
+		deletePaths = append(deletePaths, fmt.Sprintf("%v/read-timeout", data.getPath()))
+	}
+	if !data.WriteTimeout.IsNull() {
+		deletePaths = append(deletePaths, fmt.Sprintf("%v/write-timeout", data.getPath()))
+	}
 	if !data.Authgroup.IsNull() {
 		deletePaths = append(deletePaths, fmt.Sprintf("%v/authgroup", data.getPath()))
 	}
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/netascode/go-restconf"
 	"github.com/tidwall/gjson"
@@ -30,13 +31,15 @@ import (
 )
 
 type DeviceConfig struct {
-	Instance   types.String       `tfsdk:"instance"`
-	Id         types.String       `tfsdk:"id"`
-	Device     types.String       `tfsdk:"device"`
-	Path       types.String       `tfsdk:"path"`
-	Delete     types.Bool         `tfsdk:"delete"`
-	Attributes types.Map          `tfsdk:"attributes"`
-	Lists      []DeviceConfigList `tfsdk:"lists"`
+	Instance           types.String       `tfsdk:"instance"`
+	Id                 types.String       `tfsdk:"id"`
+	Device             types.String       `tfsdk:"device"`
+	Path               types.String       `tfsdk:"path"`
+	Delete             types.Bool         `tfsdk:"delete"`
+	OutOfSyncBehaviour types.String       `tfsdk:"out_of_sync_behaviour"`
+	DeviceInSync       types.Bool         `tfsdk:"device_in_sync"`
+	Attributes         types.Map          `tfsdk:"attributes"`
+	Lists              []DeviceConfigList `tfsdk:"lists"`
 }
 
 type DeviceConfigList struct {
@@ -54,11 +57,45 @@ type DeviceConfigData struct {
 	Attributes types.Map    `tfsdk:"attributes"`
 }
 
+type DeviceConfigIdentity struct {
+	Instance types.String `tfsdk:"instance"`
+	Device   types.String `tfsdk:"device"`
+	Path     types.String `tfsdk:"path"`
+}
+
+type DeviceConfigs struct {
+	Instance types.String `tfsdk:"instance"`
+	Device   types.String `tfsdk:"device"`
+	Path     types.String `tfsdk:"path"`
+	Key      types.String `tfsdk:"key"`
+}
+
 func (data DeviceConfig) getPath() string {
-	if data.Path.ValueString() != "" {
-		return "tailf-ncs:devices/device=" + data.Device.ValueString() + "/config/" + data.Path.ValueString()
-	} else {
-		return "tailf-ncs:devices/device=" + data.Device.ValueString() + "/config"
+	return helpers.DeviceConfigPath(data.Device.ValueString(), data.Path.ValueString())
+}
+
+func (data DeviceConfig) getIdentity() DeviceConfigIdentity {
+	return DeviceConfigIdentity{
+		Instance: data.Instance,
+		Device:   data.Device,
+		Path:     data.Path,
+	}
+}
+
+// Device actions are invoked by a POST request to the action below the device
+func (data DeviceConfig) getDeviceActionPath(action string) string {
+	return "tailf-ncs:devices/device=" + helpers.EncodeKey(data.Device.ValueString()) + "/" + action
+}
+
+// The check-sync result is either "in-sync", "out-of-sync" or a reason why the state is unknown, e.g. "unsupported"
+func (data *DeviceConfig) fromCheckSync(res gjson.Result) {
+	switch actionOutput(res).Get("result").String() {
+	case "in-sync":
+		data.DeviceInSync = types.BoolValue(true)
+	case "out-of-sync":
+		data.DeviceInSync = types.BoolValue(false)
+	default:
+		data.DeviceInSync = types.BoolNull()
 	}
 }
 
@@ -96,6 +133,7 @@ func (data DeviceConfig) toBody(ctx context.Context) string {
 				data.Lists[i].Items[ii].ElementsAs(ctx, &listAttributes, false)
 				attrs := restconf.Body{}
 				for attr, value := range listAttributes {
+					attr = strings.ReplaceAll(attr, "/", ".")
 					attrs = attrs.Set(attr, value)
 				}
 				body, _ = sjson.SetRaw(body, root+"."+listName+".-1", attrs.Str)
@@ -192,7 +230,6 @@ func (data *DeviceConfig) getDeletedListItems(ctx context.Context, state DeviceC
 	deletedListItems := make([]string, 0)
 	for l := range state.Lists {
 		name := state.Lists[l].Name.ValueString()
-		namePath := strings.ReplaceAll(name, "/", ".")
 		keys := strings.Split(state.Lists[l].Key.ValueString(), ",")
 		var dataList DeviceConfigList
 		for _, dl := range data.Lists {
@@ -242,7 +279,7 @@ func (data *DeviceConfig) getDeletedListItems(ctx context.Context, state DeviceC
 					for k, key := range keys {
 						keyValues[k] = slia[key]
 					}
-					deletedListItems = append(deletedListItems, state.getPath()+"/"+namePath+"="+strings.Join(keyValues, ","))
+					deletedListItems = append(deletedListItems, state.getPath()+"/"+name+"="+helpers.EncodeKey(keyValues...))
 				}
 			}
 		} else if len(state.Lists[l].Values.Elements()) > 0 {
@@ -260,10 +297,90 @@ func (data *DeviceConfig) getDeletedListItems(ctx context.Context, state DeviceC
 					}
 				}
 				if !found {
-					deletedListItems = append(deletedListItems, state.getPath()+"/"+namePath+"="+stateValue)
+					deletedListItems = append(deletedListItems, state.getPath()+"/"+name+"="+helpers.EncodeKey(stateValue))
 				}
 			}
 		}
 	}
 	return deletedListItems
 }
+
+// Read all leafs and leaf-lists below the path, nested containers are flattened to attribute names separated by "/".
+// Nested lists are skipped as their keys are not known without the YANG model.
+func (data *DeviceConfig) fromBodyAll(ctx context.Context, res gjson.Result) {
+	prefix := helpers.LastElement(data.getPath())
+	if res.Get(prefix).IsArray() {
+		prefix += ".0"
+	}
+	attributes := make(map[string]attr.Value)
+	data.Lists = nil
+	var flatten func(name string, value gjson.Result)
+	flatten = func(name string, value gjson.Result) {
+		value.ForEach(func(k, v gjson.Result) bool {
+			attrName := name + k.String()
+			if (v.IsObject() && len(v.Map()) == 0) || v.Raw == "[null]" {
+				attributes[attrName] = types.StringValue("")
+			} else if v.IsObject() {
+				flatten(attrName+"/", v)
+			} else if v.IsArray() {
+				values := v.Array()
+				for _, value := range values {
+					if value.IsObject() || value.IsArray() {
+						return true
+					}
+				}
+				data.Lists = append(data.Lists, DeviceConfigList{
+					Name:   types.StringValue(attrName),
+					Key:    types.StringNull(),
+					Values: types.ListValueMust(types.StringType, helpers.GetValueSlice(values)),
+				})
+			} else {
+				attributes[attrName] = types.StringValue(v.String())
+			}
+			return true
+		})
+	}
+	flatten("", res.Get(prefix))
+	data.Attributes = types.MapValueMust(types.StringType, attributes)
+}
+
+func (data DeviceConfigs) getPath() string {
+	return DeviceConfig{Device: data.Device, Path: data.Path}.getPath()
+}
+
+// The path refers to a YANG list if the response is an array and the last path element has no key
+func (data DeviceConfigs) isList(res gjson.Result) bool {
+	elements := strings.Split(data.getPath(), "/")
+	return res.Get(helpers.LastElement(data.getPath())).IsArray() && !strings.Contains(elements[len(elements)-1], "=")
+}
+
+// Resource models of the object at the path, or of all list entries if the path refers to a YANG list
+func (data DeviceConfigs) fromBody(ctx context.Context, res gjson.Result) []DeviceConfig {
+	resources := make([]DeviceConfig, 0)
+	newResource := func(path types.String, body gjson.Result) DeviceConfig {
+		r := DeviceConfig{
+			Instance: data.Instance,
+			Device:   data.Device,
+			Path:     path,
+			Delete:   types.BoolValue(true),
+		}
+		r.Id = types.StringValue(r.getPath())
+		r.fromBodyAll(ctx, body)
+		return r
+	}
+	if !data.isList(res) {
+		return append(resources, newResource(data.Path, res))
+	}
+	element := helpers.LastElement(data.getPath())
+	keys := strings.Split(data.Key.ValueString(), ",")
+	res.Get(element).ForEach(func(_, v gjson.Result) bool {
+		keyValues := make([]string, len(keys))
+		for i, key := range keys {
+			keyValues[i] = v.Get(key).String()
+		}
+		path := types.StringValue(data.Path.ValueString() + "=" + helpers.EncodeKey(keyValues...))
+		resources = append(resources, newResource(path, gjson.Parse(`{"`+element+`":[`+v.Raw+`]}`)))
+		return true
+	})
+	return resources
+}
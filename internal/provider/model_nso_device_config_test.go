@@ -0,0 +1,275 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+func TestDeviceConfigModelPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		want      string
+		wantShort string
+	}{
+		{"root", "", "tailf-ncs:devices/device=ce0/config", "tailf-ncs:devices/device=ce0/config"},
+		{"container", "tailf-ned-cisco-ios:hostname", "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:hostname", "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:hostname"},
+		{"list entry", "tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := DeviceConfig{Device: types.StringValue("ce0"), Path: types.StringValue(tt.path)}
+			if got := data.getPath(); got != tt.want {
+				t.Errorf("getPath() = %q, want %q", got, tt.want)
+			}
+			if got := data.getPathShort(); got != tt.wantShort {
+				t.Errorf("getPathShort() = %q, want %q", got, tt.wantShort)
+			}
+		})
+	}
+	data := DeviceConfig{Device: types.StringValue("ce0")}
+	if got, want := data.getDeviceActionPath("check-sync"), "tailf-ncs:devices/device=ce0/check-sync"; got != want {
+		t.Errorf("getDeviceActionPath() = %q, want %q", got, want)
+	}
+}
+
+func TestDeviceConfigModelCheckSync(t *testing.T) {
+	tests := []struct {
+		body string
+		want types.Bool
+	}{
+		{`{"tailf-ncs:output":{"result":"in-sync"}}`, types.BoolValue(true)},
+		{`{"tailf-ncs:output":{"result":"out-of-sync","info":"got: 1 expected: 2"}}`, types.BoolValue(false)},
+		{`{"tailf-ncs:output":{"result":"unsupported"}}`, types.BoolNull()},
+		{`{}`, types.BoolNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			var data DeviceConfig
+			data.fromCheckSync(gjson.Parse(tt.body))
+			if !data.DeviceInSync.Equal(tt.want) {
+				t.Errorf("DeviceInSync = %v, want %v", data.DeviceInSync, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceConfigModelToBody(t *testing.T) {
+	tests := []struct {
+		name string
+		data DeviceConfig
+		want string
+	}{
+		{
+			// the root of the device configuration has to be sent without module prefix
+			name: "root",
+			data: DeviceConfig{
+				Device:     types.StringValue("ce0"),
+				Attributes: testStringMap(map[string]string{"tailf-ned-cisco-ios:hostname": "R1"}),
+			},
+			want: `{"config":{"tailf-ned-cisco-ios:hostname":"R1"}}`,
+		},
+		{
+			name: "path",
+			data: DeviceConfig{
+				Device:     types.StringValue("ce0"),
+				Path:       types.StringValue("tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1"),
+				Attributes: testStringMap(map[string]string{"name": "0/1", "ip/address/primary/address": "1.1.1.1"}),
+			},
+			want: `{"tailf-ned-cisco-ios:GigabitEthernet":{"name":"0/1","ip":{"address":{"primary":{"address":"1.1.1.1"}}}}}`,
+		},
+		{
+			name: "lists",
+			data: DeviceConfig{
+				Device:     types.StringValue("ce0"),
+				Path:       types.StringValue("tailf-ned-cisco-ios:interface"),
+				Attributes: testStringMap(map[string]string{}),
+				Lists: []DeviceConfigList{
+					{
+						Name:   types.StringValue("Loopback"),
+						Key:    types.StringValue("name"),
+						Items:  []types.Map{testStringMap(map[string]string{"name": "1", "description/text": "test"})},
+						Values: types.ListNull(types.StringType),
+					},
+					{
+						Name:   types.StringValue("vlan/vlan-list"),
+						Values: testStringList("1", "2"),
+					},
+				},
+			},
+			want: `{"tailf-ned-cisco-ios:interface":{"Loopback":[{"name":"1","description":{"text":"test"}}],"vlan":{"vlan-list":["1","2"]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONEqual(t, tt.want, tt.data.toBody(context.Background()))
+		})
+	}
+}
+
+func TestDeviceConfigModelToBodyNestedListAttributes(t *testing.T) {
+	data := DeviceConfig{
+		Device:     types.StringValue("ce0"),
+		Path:       types.StringValue("tailf-ned-cisco-ios:interface"),
+		Attributes: testStringMap(map[string]string{}),
+		Lists: []DeviceConfigList{
+			{
+				Name:   types.StringValue("Loopback"),
+				Key:    types.StringValue("name"),
+				Items:  []types.Map{testStringMap(map[string]string{"name": "1", "description/text": "test"})},
+				Values: types.ListNull(types.StringType),
+			},
+		},
+	}
+	body := data.toBody(context.Background())
+	if got := gjson.Get(body, "tailf-ned-cisco-ios:interface.Loopback.0.description.text").String(); got != "test" {
+		t.Errorf("toBody() = %s, want nested description/text of list item", body)
+	}
+}
+
+func TestDeviceConfigModelDeletedListItems(t *testing.T) {
+	device := types.StringValue("ce0")
+	path := types.StringValue("tailf-ned-cisco-ios:interface")
+	plan := DeviceConfig{Device: device, Path: path, Lists: []DeviceConfigList{
+		{Name: types.StringValue("Loopback"), Key: types.StringValue("name"), Items: []types.Map{testStringMap(map[string]string{"name": "1"})}},
+		{Name: types.StringValue("vlan/vlan-list"), Values: testStringList("1")},
+	}}
+	state := DeviceConfig{Device: device, Path: path, Lists: []DeviceConfigList{
+		{Name: types.StringValue("Loopback"), Key: types.StringValue("name"), Items: []types.Map{
+			testStringMap(map[string]string{"name": "1"}),
+			testStringMap(map[string]string{"name": "2"}),
+			testStringMap(map[string]string{"name": ""}),
+		}},
+		{Name: types.StringValue("GigabitEthernet"), Key: types.StringValue("name"), Items: []types.Map{testStringMap(map[string]string{"name": "0/1"})}},
+		{Name: types.StringValue("vlan/vlan-list"), Values: testStringList("1", "2")},
+	}}
+	want := []string{
+		"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/Loopback=2",
+		"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1",
+		"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/vlan/vlan-list=2",
+	}
+	if got := plan.getDeletedListItems(context.Background(), state); !reflect.DeepEqual(got, want) {
+		t.Errorf("getDeletedListItems() = %v, want %v", got, want)
+	}
+	if got := state.getDeletedListItems(context.Background(), state); len(got) > 0 {
+		t.Errorf("getDeletedListItems() of unchanged lists = %v", got)
+	}
+}
+
+func TestDeviceConfigModelRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	property := func(seed int64, withPath bool) bool {
+		attributes, restconfLists := testRandomRestconfAttributes(rand.New(rand.NewSource(seed)))
+		lists := make([]DeviceConfigList, len(restconfLists))
+		for i, l := range restconfLists {
+			lists[i] = DeviceConfigList{Name: l.Name, Key: l.Key, Items: l.Items, Values: l.Values}
+		}
+		plan := DeviceConfig{Device: types.StringValue("ce0"), Path: types.StringNull(), Attributes: attributes, Lists: lists}
+		if withPath {
+			plan.Path = types.StringValue("tailf-ned-cisco-ios:interface/Loopback=1")
+		}
+		body := plan.toBody(ctx)
+		// NSO returns the root of the device configuration with module prefix
+		if !withPath {
+			body = strings.Replace(body, `{"config":`, `{"tailf-ncs:config":`, 1)
+		}
+
+		read := plan
+		read.Lists = make([]DeviceConfigList, len(lists))
+		copy(read.Lists, lists)
+		read.fromBody(ctx, gjson.Parse(body))
+		if !read.Attributes.Equal(plan.Attributes) || !reflect.DeepEqual(read.Lists, plan.Lists) {
+			t.Errorf("fromBody of %s:\nwant %v %v\ngot %v %v", body, plan.Attributes, plan.Lists, read.Attributes, read.Lists)
+			return false
+		}
+		if deleted := read.getDeletedListItems(ctx, plan); len(deleted) > 0 {
+			t.Errorf("unexpected deleted list items %s", strings.Join(deleted, ", "))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: testModelIterations}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeviceConfigModelListFromBody(t *testing.T) {
+	ctx := context.Background()
+	interfaces := DeviceConfigs{
+		Device: types.StringValue("ce0"),
+		Path:   types.StringValue("tailf-ned-cisco-ios:interface/GigabitEthernet"),
+		Key:    types.StringValue("name"),
+	}
+	body := `{"tailf-ned-cisco-ios:GigabitEthernet":[
+		{"name":"0/1","description":"uplink","shutdown":[null],"ip":{"address":{"primary":{"address":"10.0.0.1","mask":"255.255.255.0"}}}},
+		{"name":"0/2","switchport":{"trunk":{"allowed":{"vlan":{"vlans":[10,20]}}}},"service-policy":[{"direction":"input","name":"p1"}]}
+	]}`
+	res := gjson.Parse(body)
+	if !interfaces.isList(res) {
+		t.Fatal("isList = false for a list path")
+	}
+	resources := interfaces.fromBody(ctx, res)
+	if len(resources) != 2 {
+		t.Fatalf("want 2 resources, got %d", len(resources))
+	}
+
+	if got, want := resources[0].getPath(), "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1"; got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+	var attributes map[string]string
+	resources[0].Attributes.ElementsAs(ctx, &attributes, false)
+	want := map[string]string{
+		"name":                       "0/1",
+		"description":                "uplink",
+		"shutdown":                   "",
+		"ip/address/primary/address": "10.0.0.1",
+		"ip/address/primary/mask":    "255.255.255.0",
+	}
+	if !reflect.DeepEqual(attributes, want) {
+		t.Errorf("attributes = %v, want %v", attributes, want)
+	}
+	if identity := resources[0].getIdentity(); identity.Device.ValueString() != "ce0" || identity.Path.ValueString() != "tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+
+	// leaf-lists are read, nested lists are skipped as their keys are unknown
+	if len(resources[1].Lists) != 1 || resources[1].Lists[0].Name.ValueString() != "switchport/trunk/allowed/vlan/vlans" {
+		t.Fatalf("unexpected lists %+v", resources[1].Lists)
+	}
+	testJSONEqual(t, `{"tailf-ned-cisco-ios:GigabitEthernet":{"name":"0/2","switchport":{"trunk":{"allowed":{"vlan":{"vlans":["10","20"]}}}}}}`, resources[1].toBody(ctx))
+
+	// the path of a single list entry returns one resource
+	entry := interfaces
+	entry.Path = types.StringValue(resources[0].Path.ValueString())
+	entryBody := gjson.Parse(`{"tailf-ned-cisco-ios:GigabitEthernet":[` + res.Get("tailf-ned-cisco-ios:GigabitEthernet.0").Raw + `]}`)
+	if entry.isList(entryBody) {
+		t.Error("isList = true for a list entry path")
+	}
+	if entries := entry.fromBody(ctx, entryBody); len(entries) != 1 || entries[0].Path != entry.Path || !entries[0].Attributes.Equal(resources[0].Attributes) {
+		t.Errorf("list entry: unexpected resources %+v", entries)
+	}
+}
@@ -22,7 +22,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"regexp"
 
 	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
@@ -35,24 +34,47 @@ type DeviceGroup struct {
 	Instance     types.String `tfsdk:"instance"`
 	Id           types.String `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
-	DeviceNames  types.List   `tfsdk:"device_names"`
-	DeviceGroups types.List   `tfsdk:"device_groups"`
+	DeviceNames  types.Set    `tfsdk:"device_names"`
+	DeviceGroups types.Set    `tfsdk:"device_groups"`
 }
 
 type DeviceGroupData struct {
 	Instance     types.String `tfsdk:"instance"`
 	Id           types.String `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
-	DeviceNames  types.List   `tfsdk:"device_names"`
-	DeviceGroups types.List   `tfsdk:"device_groups"`
+	DeviceNames  types.Set    `tfsdk:"device_names"`
+	DeviceGroups types.Set    `tfsdk:"device_groups"`
+}
+
+type DeviceGroups struct {
+	Instance types.String       `tfsdk:"instance"`
+	Id       types.String       `tfsdk:"id"`
+	Name     types.String       `tfsdk:"name"`
+	Items    []DeviceGroupsItem `tfsdk:"device_groups"`
+}
+
+type DeviceGroupsItem struct {
+	Name         types.String `tfsdk:"name"`
+	DeviceNames  types.Set    `tfsdk:"device_names"`
+	DeviceGroups types.Set    `tfsdk:"device_groups"`
+}
+
+type DeviceGroupIdentity struct {
+	Instance types.String `tfsdk:"instance"`
+	Name     types.String `tfsdk:"name"`
+}
+
+type DeviceGroupsList struct {
+	Instance types.String `tfsdk:"instance"`
+	Name     types.String `tfsdk:"name"`
 }
 
 func (data DeviceGroup) getPath() string {
-	return fmt.Sprintf("tailf-ncs:devices/device-group=%v", url.QueryEscape(fmt.Sprintf("%v", data.Name.ValueString())))
+	return fmt.Sprintf("tailf-ncs:devices/device-group=%v", helpers.EncodeKey(fmt.Sprintf("%v", data.Name.ValueString())))
 }
 
 func (data DeviceGroupData) getPath() string {
-	return fmt.Sprintf("tailf-ncs:devices/device-group=%v", url.QueryEscape(fmt.Sprintf("%v", data.Name.ValueString())))
+	return fmt.Sprintf("tailf-ncs:devices/device-group=%v", helpers.EncodeKey(fmt.Sprintf("%v", data.Name.ValueString())))
 }
 
 // if last path element has a key -> remove it
@@ -66,6 +88,26 @@ func (data DeviceGroup) getPathShort() string {
 	return matches[1]
 }
 
+// The key values are taken from the RESTCONF path, as only the id is known after an import
+func (data DeviceGroup) getIdentity() DeviceGroupIdentity {
+	identity := DeviceGroupIdentity{Instance: data.Instance}
+	keys := helpers.PathKeys("tailf-ncs:devices/device-group=%v", data.Id.ValueString())
+	if keys == nil {
+		identity.Name = data.Name
+		return identity
+	}
+	identity.Name = types.StringValue(keys[0])
+	return identity
+}
+
+// Resource model with the key values of an identity, as used by an import with the identity attribute
+func (data DeviceGroupIdentity) toResource() DeviceGroup {
+	return DeviceGroup{
+		Instance: data.Instance,
+		Name:     data.Name,
+	}
+}
+
 func (data DeviceGroup) toBody(ctx context.Context) string {
 	body := `{"` + helpers.LastElement(data.getPath()) + `":{}}`
 	if !data.Name.IsNull() && !data.Name.IsUnknown() {
@@ -95,14 +137,14 @@ func (data *DeviceGroup) updateFromBody(ctx context.Context, res gjson.Result) {
 		data.Name = types.StringNull()
 	}
 	if value := res.Get(prefix + "device-name"); value.Exists() && !data.DeviceNames.IsNull() {
-		data.DeviceNames = helpers.GetStringList(value.Array())
+		data.DeviceNames = helpers.GetStringSet(value.Array())
 	} else {
-		data.DeviceNames = types.ListNull(types.StringType)
+		data.DeviceNames = types.SetNull(types.StringType)
 	}
 	if value := res.Get(prefix + "device-group"); value.Exists() && !data.DeviceGroups.IsNull() {
-		data.DeviceGroups = helpers.GetStringList(value.Array())
+		data.DeviceGroups = helpers.GetStringSet(value.Array())
 	} else {
-		data.DeviceGroups = types.ListNull(types.StringType)
+		data.DeviceGroups = types.SetNull(types.StringType)
 	}
 }
 
@@ -112,15 +154,70 @@ func (data *DeviceGroupData) fromBody(ctx context.Context, res gjson.Result) {
 		prefix += "0."
 	}
 	if value := res.Get(prefix + "device-name"); value.Exists() {
-		data.DeviceNames = helpers.GetStringList(value.Array())
+		data.DeviceNames = helpers.GetStringSet(value.Array())
 	} else {
-		data.DeviceNames = types.ListNull(types.StringType)
+		data.DeviceNames = types.SetNull(types.StringType)
 	}
 	if value := res.Get(prefix + "device-group"); value.Exists() {
-		data.DeviceGroups = helpers.GetStringList(value.Array())
+		data.DeviceGroups = helpers.GetStringSet(value.Array())
 	} else {
-		data.DeviceGroups = types.ListNull(types.StringType)
+		data.DeviceGroups = types.SetNull(types.StringType)
+	}
+}
+
+func (data DeviceGroups) getPath() string {
+	return "tailf-ncs:devices/device-group"
+}
+
+// Path of the list entry if all key filters are set, otherwise path of the list
+func (data DeviceGroups) getReadPath() string {
+	if data.Name.IsNull() {
+		return data.getPath()
+	}
+	return fmt.Sprintf("tailf-ncs:devices/device-group=%v", helpers.EncodeKey(fmt.Sprintf("%v", data.Name.ValueString())))
+}
+
+func (data *DeviceGroups) fromBody(ctx context.Context, res gjson.Result) {
+	data.Items = make([]DeviceGroupsItem, 0)
+	element := helpers.LastElement(data.getPath())
+	res.Get(element).ForEach(func(k, v gjson.Result) bool {
+		entry := DeviceGroupData{}
+		entry.fromBody(ctx, gjson.Parse(`{"`+element+`":`+v.Raw+`}`))
+		item := DeviceGroupsItem{}
+		item.Name = types.StringValue(v.Get("name").String())
+		if !data.Name.IsNull() && !data.Name.Equal(item.Name) {
+			return true
+		}
+		item.DeviceNames = entry.DeviceNames
+		item.DeviceGroups = entry.DeviceGroups
+		data.Items = append(data.Items, item)
+		return true
+	})
+}
+
+func (data DeviceGroupsList) getList() DeviceGroups {
+	return DeviceGroups{
+		Instance: data.Instance,
+		Name:     data.Name,
+	}
+}
+
+// Resource models of all list entries, as read by the list resource
+func (data DeviceGroupsList) fromBody(ctx context.Context, res gjson.Result) []DeviceGroup {
+	list := data.getList()
+	list.fromBody(ctx, res)
+	resources := make([]DeviceGroup, 0, len(list.Items))
+	for _, item := range list.Items {
+		r := DeviceGroup{
+			Instance:     data.Instance,
+			Name:         item.Name,
+			DeviceNames:  item.DeviceNames,
+			DeviceGroups: item.DeviceGroups,
+		}
+		r.Id = types.StringValue(r.getPath())
+		resources = append(resources, r)
 	}
+	return resources
 }
 
 func (data *DeviceGroup) getDeletedListItems(ctx context.Context, state DeviceGroup) []string {
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/netascode/go-restconf"
 	"github.com/tidwall/gjson"
@@ -52,6 +53,13 @@ type RestconfDataSourceModel struct {
 	Attributes types.Map    `tfsdk:"attributes"`
 }
 
+type RestconfEphemeralModel struct {
+	Instance   types.String `tfsdk:"instance"`
+	Path       types.String `tfsdk:"path"`
+	Input      types.Map    `tfsdk:"input"`
+	Attributes types.Map    `tfsdk:"attributes"`
+}
+
 func (data Restconf) getPath() string {
 	return data.Path.ValueString()
 }
@@ -179,11 +187,61 @@ func (data *Restconf) fromBody(ctx context.Context, res gjson.Result) {
 	}
 }
 
+// The input leafs of an action are set like the attributes of the nso_restconf resource, nested leafs are separated by "/"
+func (data RestconfEphemeralModel) toBody(ctx context.Context) string {
+	body := `{"input":{}}`
+
+	var input map[string]string
+	data.Input.ElementsAs(ctx, &input, false)
+
+	for attr, value := range input {
+		attr = strings.ReplaceAll(attr, "/", ".")
+		body, _ = sjson.Set(body, "input."+attr, value)
+	}
+	return body
+}
+
+// The attributes are read from the object at the path or, if an action was invoked, from its output
+func (data *RestconfEphemeralModel) fromBody(ctx context.Context, res gjson.Result) {
+	if data.Input.IsNull() {
+		object := res.Get(helpers.LastElement(data.Path.ValueString()))
+		// list entries are returned as array
+		if object.IsArray() {
+			object = object.Get("0")
+		}
+		data.Attributes = restconfAttributes(object)
+		return
+	}
+	var output gjson.Result
+	res.ForEach(func(_, v gjson.Result) bool {
+		output = v
+		return false
+	})
+	data.Attributes = restconfAttributes(output)
+}
+
+// restconfAttributes converts the leafs of a RESTCONF object to a map of strings, empty leafs are represented by an
+// empty string
+func restconfAttributes(res gjson.Result) types.Map {
+	attributes := make(map[string]attr.Value)
+
+	for attr, value := range res.Map() {
+		// handle empty maps
+		if value.IsObject() && len(value.Map()) == 0 {
+			attributes[attr] = types.StringValue("")
+		} else if value.Raw == "[null]" {
+			attributes[attr] = types.StringValue("")
+		} else {
+			attributes[attr] = types.StringValue(value.String())
+		}
+	}
+	return types.MapValueMust(types.StringType, attributes)
+}
+
 func (data *Restconf) getDeletedListItems(ctx context.Context, state Restconf) []string {
 	deletedListItems := make([]string, 0)
 	for l := range state.Lists {
 		name := state.Lists[l].Name.ValueString()
-		namePath := strings.ReplaceAll(name, "/", ".")
 		keys := strings.Split(state.Lists[l].Key.ValueString(), ",")
 		var dataList RestconfList
 		for _, dl := range data.Lists {
@@ -233,7 +291,7 @@ func (data *Restconf) getDeletedListItems(ctx context.Context, state Restconf) [
 					for k, key := range keys {
 						keyValues[k] = slia[key]
 					}
-					deletedListItems = append(deletedListItems, state.getPath()+"/"+namePath+"="+strings.Join(keyValues, ","))
+					deletedListItems = append(deletedListItems, state.getPath()+"/"+name+"="+helpers.EncodeKey(keyValues...))
 				}
 			}
 		} else if len(state.Lists[l].Values.Elements()) > 0 {
@@ -251,7 +309,7 @@ func (data *Restconf) getDeletedListItems(ctx context.Context, state Restconf) [
 					}
 				}
 				if !found {
-					deletedListItems = append(deletedListItems, state.getPath()+"/"+namePath+"="+stateValue)
+					deletedListItems = append(deletedListItems, state.getPath()+"/"+name+"="+helpers.EncodeKey(stateValue))
 				}
 			}
 		}
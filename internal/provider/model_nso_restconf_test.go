@@ -0,0 +1,311 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+func testStringMap(m map[string]string) types.Map {
+	values := make(map[string]attr.Value, len(m))
+	for k, v := range m {
+		values[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+func testStringList(values ...string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, v := range values {
+		elements[i] = types.StringValue(v)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// testRandomRestconfAttributes returns random attributes and lists of a nso_restconf resource. Attribute names are
+// unique and some of them are nested using "/".
+func testRandomRestconfAttributes(r *rand.Rand) (types.Map, []RestconfList) {
+	attributes := map[string]string{}
+	for i := 0; i < r.Intn(5); i++ {
+		name := fmt.Sprintf("leaf%d", i)
+		if r.Intn(2) == 0 {
+			name = fmt.Sprintf("container%d/leaf", i)
+		}
+		attributes[name] = testRandomString(r)
+	}
+	var lists []RestconfList
+	for i := 0; i < r.Intn(3); i++ {
+		list := RestconfList{
+			Name:   types.StringValue(fmt.Sprintf("list%d", i)),
+			Key:    types.StringValue("name"),
+			Values: types.ListNull(types.StringType),
+		}
+		if r.Intn(2) == 0 {
+			list.Name = types.StringValue(fmt.Sprintf("container%d/list", i))
+		}
+		if r.Intn(2) == 0 {
+			for j := 0; j < 1+r.Intn(3); j++ {
+				list.Items = append(list.Items, testStringMap(map[string]string{
+					"name":      fmt.Sprintf("%s%d", testRandomString(r), j),
+					"leaf":      testRandomString(r),
+					"container": testRandomString(r),
+				}))
+			}
+		} else {
+			var values []string
+			for j := 0; j < 1+r.Intn(3); j++ {
+				values = append(values, testRandomString(r))
+			}
+			list.Values = testStringList(values...)
+		}
+		lists = append(lists, list)
+	}
+	return testStringMap(attributes), lists
+}
+
+func TestRestconfModelPathShort(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"tailf-ncs:ssh", "tailf-ncs:ssh"},
+		{"tailf-ncs:devices/device=ce0", "tailf-ncs:devices/device"},
+		{"tailf-ncs:devices/device=ce0/config", "tailf-ncs:devices/device=ce0/config"},
+		{"tailf-ncs:services/l3vpn:vpn/l3vpn=a,b", "tailf-ncs:services/l3vpn:vpn/l3vpn"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data := Restconf{Path: types.StringValue(tt.path)}
+			if got := data.getPathShort(); got != tt.want {
+				t.Errorf("getPathShort() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestconfModelToBody(t *testing.T) {
+	tests := []struct {
+		name string
+		data Restconf
+		want string
+	}{
+		{
+			name: "empty",
+			data: Restconf{Path: types.StringValue("tailf-ncs:ssh"), Attributes: types.MapNull(types.StringType)},
+			want: `{"tailf-ncs:ssh":{}}`,
+		},
+		{
+			name: "attributes",
+			data: Restconf{
+				Path:       types.StringValue("tailf-ncs:customers/customer=123"),
+				Attributes: testStringMap(map[string]string{"id": "123", "address/city": "Zurich"}),
+			},
+			want: `{"tailf-ncs:customer":{"id":"123","address":{"city":"Zurich"}}}`,
+		},
+		{
+			name: "list items",
+			data: Restconf{
+				Path:       types.StringValue("tailf-ncs:devices/authgroups/group=g1"),
+				Attributes: testStringMap(map[string]string{"name": "g1"}),
+				Lists: []RestconfList{{
+					Name: types.StringValue("umap"),
+					Key:  types.StringValue("local-user"),
+					Items: []types.Map{
+						testStringMap(map[string]string{"local-user": "admin", "remote-name": "admin"}),
+						testStringMap(map[string]string{"local-user": "oper", "remote-name": "oper"}),
+					},
+					Values: types.ListNull(types.StringType),
+				}},
+			},
+			want: `{"tailf-ncs:group":{"name":"g1","umap":[{"local-user":"admin","remote-name":"admin"},{"local-user":"oper","remote-name":"oper"}]}}`,
+		},
+		{
+			name: "nested list values",
+			data: Restconf{
+				Path:       types.StringValue("tailf-ncs:devices/device-group=g1"),
+				Attributes: testStringMap(map[string]string{}),
+				Lists: []RestconfList{{
+					Name:   types.StringValue("members/device-name"),
+					Values: testStringList("ce0", "ce1"),
+				}},
+			},
+			want: `{"tailf-ncs:device-group":{"members":{"device-name":["ce0","ce1"]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONEqual(t, tt.want, tt.data.toBody(context.Background()))
+		})
+	}
+}
+
+func TestRestconfModelFromBody(t *testing.T) {
+	data := Restconf{
+		Path:       types.StringValue("tailf-ncs:customers/customer=123"),
+		Attributes: testStringMap(map[string]string{"id": "", "rank": "", "address/city": "", "presence": "", "empty": "", "missing": "x"}),
+		Lists: []RestconfList{
+			{
+				Name: types.StringValue("contact"),
+				Key:  types.StringValue("first,last"),
+				Items: []types.Map{
+					testStringMap(map[string]string{"first": "A", "last": "B", "phone": ""}),
+					testStringMap(map[string]string{"first": "A", "last": "C", "phone": "x"}),
+				},
+				Values: types.ListNull(types.StringType),
+			},
+			{
+				Name:   types.StringValue("tags/tag"),
+				Values: testStringList("old"),
+			},
+		},
+	}
+	res := gjson.Parse(`{"tailf-ncs:customer":[{
+		"id": "123",
+		"rank": 5,
+		"address": {"city": "Zurich"},
+		"presence": {},
+		"empty": [null],
+		"contact": [{"first": "A", "last": "C"}, {"first": "A", "last": "B", "phone": "123"}],
+		"tags": {"tag": ["a", "b"]}
+	}]}`)
+	data.fromBody(context.Background(), res)
+
+	wantAttributes := testStringMap(map[string]string{"id": "123", "rank": "5", "address/city": "Zurich", "presence": "", "empty": "", "missing": ""})
+	if !data.Attributes.Equal(wantAttributes) {
+		t.Errorf("attributes = %v, want %v", data.Attributes, wantAttributes)
+	}
+	wantItems := []types.Map{
+		testStringMap(map[string]string{"first": "A", "last": "B", "phone": "123"}),
+		testStringMap(map[string]string{"first": "A", "last": "C", "phone": ""}),
+	}
+	if !reflect.DeepEqual(data.Lists[0].Items, wantItems) {
+		t.Errorf("list items = %v, want %v", data.Lists[0].Items, wantItems)
+	}
+	if want := testStringList("a", "b"); !data.Lists[1].Values.Equal(want) {
+		t.Errorf("list values = %v, want %v", data.Lists[1].Values, want)
+	}
+}
+
+func TestRestconfModelDeletedListItems(t *testing.T) {
+	path := types.StringValue("tailf-ncs:devices/authgroups/group=g1")
+	items := func(keys ...string) []types.Map {
+		var m []types.Map
+		for _, k := range keys {
+			m = append(m, testStringMap(map[string]string{"local-user": k}))
+		}
+		return m
+	}
+	tests := []struct {
+		name  string
+		plan  []RestconfList
+		state []RestconfList
+		want  []string
+	}{
+		{
+			name:  "unchanged",
+			plan:  []RestconfList{{Name: types.StringValue("umap"), Key: types.StringValue("local-user"), Items: items("a", "b")}},
+			state: []RestconfList{{Name: types.StringValue("umap"), Key: types.StringValue("local-user"), Items: items("a", "b")}},
+			want:  []string{},
+		},
+		{
+			name:  "item removed",
+			plan:  []RestconfList{{Name: types.StringValue("umap"), Key: types.StringValue("local-user"), Items: items("b")}},
+			state: []RestconfList{{Name: types.StringValue("umap"), Key: types.StringValue("local-user"), Items: items("a", "b")}},
+			want:  []string{"tailf-ncs:devices/authgroups/group=g1/umap=a"},
+		},
+		{
+			name:  "list removed",
+			state: []RestconfList{{Name: types.StringValue("umap"), Key: types.StringValue("local-user"), Items: items("a", "b")}},
+			want:  []string{"tailf-ncs:devices/authgroups/group=g1/umap=a", "tailf-ncs:devices/authgroups/group=g1/umap=b"},
+		},
+		{
+			name:  "empty key",
+			state: []RestconfList{{Name: types.StringValue("umap"), Key: types.StringValue("local-user"), Items: items("")}},
+			want:  []string{},
+		},
+		{
+			name: "multiple keys",
+			plan: []RestconfList{{Name: types.StringValue("contact"), Key: types.StringValue("first,last"), Items: []types.Map{
+				testStringMap(map[string]string{"first": "A", "last": "B"}),
+			}}},
+			state: []RestconfList{{Name: types.StringValue("contact"), Key: types.StringValue("first,last"), Items: []types.Map{
+				testStringMap(map[string]string{"first": "A", "last": "B"}),
+				testStringMap(map[string]string{"first": "A", "last": "C"}),
+			}}},
+			want: []string{"tailf-ncs:devices/authgroups/group=g1/contact=A,C"},
+		},
+		{
+			name:  "nested list and escaped key",
+			plan:  []RestconfList{{Name: types.StringValue("interface/GigabitEthernet"), Key: types.StringValue("name")}},
+			state: []RestconfList{{Name: types.StringValue("interface/GigabitEthernet"), Key: types.StringValue("name"), Items: []types.Map{testStringMap(map[string]string{"name": "0/1"})}}},
+			want:  []string{"tailf-ncs:devices/authgroups/group=g1/interface/GigabitEthernet=0%2F1"},
+		},
+		{
+			name:  "value removed",
+			plan:  []RestconfList{{Name: types.StringValue("device-name"), Values: testStringList("ce0")}},
+			state: []RestconfList{{Name: types.StringValue("device-name"), Values: testStringList("ce0", "ce1")}},
+			want:  []string{"tailf-ncs:devices/authgroups/group=g1/device-name=ce1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Restconf{Path: path, Lists: tt.plan}
+			state := Restconf{Path: path, Lists: tt.state}
+			got := plan.getDeletedListItems(context.Background(), state)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDeletedListItems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestconfModelRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	property := func(seed int64) bool {
+		attributes, lists := testRandomRestconfAttributes(rand.New(rand.NewSource(seed)))
+		plan := Restconf{Path: types.StringValue("tailf-ncs:customers/customer=123"), Attributes: attributes, Lists: lists}
+		body := plan.toBody(ctx)
+
+		// the state initially holds the configured attribute names with empty values, like after an import
+		_, state := testRandomRestconfAttributes(rand.New(rand.NewSource(seed)))
+		read := Restconf{Path: plan.Path, Attributes: attributes, Lists: state}
+		read.fromBody(ctx, gjson.Parse(body))
+		if !read.Attributes.Equal(plan.Attributes) || !reflect.DeepEqual(read.Lists, plan.Lists) {
+			t.Errorf("fromBody of %s:\nwant %v %v\ngot %v %v", body, plan.Attributes, plan.Lists, read.Attributes, read.Lists)
+			return false
+		}
+		if deleted := read.getDeletedListItems(ctx, plan); len(deleted) > 0 {
+			t.Errorf("unexpected deleted list items %s", strings.Join(deleted, ", "))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: testModelIterations}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const rollbackFilesPath = "tailf-rollback:rollback-files"
+
+type Rollbacks struct {
+	Instance  types.String     `tfsdk:"instance"`
+	Id        types.String     `tfsdk:"id"`
+	Label     types.String     `tfsdk:"label"`
+	Rollbacks []RollbacksEntry `tfsdk:"rollbacks"`
+}
+
+type RollbacksEntry struct {
+	Id          types.Int64  `tfsdk:"id"`
+	FixedNumber types.Int64  `tfsdk:"fixed_number"`
+	Name        types.String `tfsdk:"name"`
+	Label       types.String `tfsdk:"label"`
+	Comment     types.String `tfsdk:"comment"`
+	User        types.String `tfsdk:"user"`
+	Timestamp   types.String `tfsdk:"timestamp"`
+	Via         types.String `tfsdk:"via"`
+}
+
+type Rollback struct {
+	Instance    types.String `tfsdk:"instance"`
+	Id          types.String `tfsdk:"id"`
+	RollbackId  types.Int64  `tfsdk:"rollback_id"`
+	FixedNumber types.Int64  `tfsdk:"fixed_number"`
+	Label       types.String `tfsdk:"label"`
+	Paths       types.List   `tfsdk:"paths"`
+	Selective   types.Bool   `tfsdk:"selective"`
+}
+
+func (data Rollbacks) getPath() string {
+	return rollbackFilesPath
+}
+
+// Rollback files are ordered from the most recent (id 0) to the oldest
+func (data *Rollbacks) fromBody(ctx context.Context, res gjson.Result) {
+	data.Rollbacks = make([]RollbacksEntry, 0)
+	res.Get("tailf-rollback:rollback-files.file").ForEach(func(_, v gjson.Result) bool {
+		entry := RollbacksEntry{
+			Id:          types.Int64Value(v.Get("id").Int()),
+			FixedNumber: types.Int64Value(v.Get("fixed-number").Int()),
+			Name:        types.StringValue(v.Get("name").String()),
+			Label:       types.StringValue(v.Get("label").String()),
+			Comment:     types.StringValue(v.Get("comment").String()),
+			User:        types.StringValue(v.Get("creator").String()),
+			Timestamp:   types.StringValue(v.Get("date").String()),
+			Via:         types.StringValue(v.Get("via").String()),
+		}
+		if !data.Label.IsNull() && !data.Label.Equal(entry.Label) {
+			return true
+		}
+		data.Rollbacks = append(data.Rollbacks, entry)
+		return true
+	})
+}
+
+func (data Rollback) getPath() string {
+	return rollbackFilesPath + "/apply-rollback-file"
+}
+
+// Rollback files are identified by their fixed number when applied, as ids change with every commit
+func (data Rollback) toBody(ctx context.Context, fixedNumber int64) string {
+	body, _ := sjson.Set(`{"input":{}}`, "input.fixed-number", fixedNumber)
+	if !data.Paths.IsNull() {
+		var paths []string
+		data.Paths.ElementsAs(ctx, &paths, false)
+		body, _ = sjson.Set(body, "input.path", paths)
+	}
+	if data.Selective.ValueBool() {
+		body, _ = sjson.SetRaw(body, "input.selective", "[null]")
+	}
+	return body
+}
+
+// Find the fixed number of the rollback file selected by id, fixed number or label. If a label is used by multiple
+// commits, the most recent one is selected.
+func (data Rollback) findFixedNumber(res gjson.Result) (int64, bool) {
+	var fixedNumber int64
+	found := false
+	res.Get("tailf-rollback:rollback-files.file").ForEach(func(_, v gjson.Result) bool {
+		if (!data.RollbackId.IsNull() && v.Get("id").Int() == data.RollbackId.ValueInt64()) ||
+			(!data.FixedNumber.IsNull() && v.Get("fixed-number").Int() == data.FixedNumber.ValueInt64()) ||
+			(!data.Label.IsNull() && v.Get("label").String() == data.Label.ValueString()) {
+			fixedNumber = v.Get("fixed-number").Int()
+			found = true
+			return false
+		}
+		return true
+	})
+	return fixedNumber, found
+}
@@ -0,0 +1,97 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+const testRollbackFiles = `{"tailf-rollback:rollback-files":{"file":[
+	{"id":0,"fixed-number":10012,"name":"rollback10012","creator":"admin","date":"2023-05-02 10:00:00","via":"rest","label":"release-2","comment":"second"},
+	{"id":1,"fixed-number":10011,"name":"rollback10011","creator":"admin","date":"2023-05-01 10:00:00","via":"cli","label":"release-1"},
+	{"id":2,"fixed-number":10010,"name":"rollback10010","creator":"oper","date":"2023-04-30 10:00:00","via":"rest","label":"release-1"}
+]}}`
+
+func TestRollbackModelFindFixedNumber(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      Rollback
+		want      int64
+		wantFound bool
+	}{
+		{"id", Rollback{RollbackId: types.Int64Value(1), FixedNumber: types.Int64Null(), Label: types.StringNull()}, 10011, true},
+		{"fixed number", Rollback{RollbackId: types.Int64Null(), FixedNumber: types.Int64Value(10010), Label: types.StringNull()}, 10010, true},
+		{"most recent label", Rollback{RollbackId: types.Int64Null(), FixedNumber: types.Int64Null(), Label: types.StringValue("release-1")}, 10011, true},
+		{"not found", Rollback{RollbackId: types.Int64Value(5), FixedNumber: types.Int64Null(), Label: types.StringNull()}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := tt.data.findFixedNumber(gjson.Parse(testRollbackFiles))
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("findFixedNumber() = %d, %v, want %d, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestRollbackModelToBody(t *testing.T) {
+	ctx := context.Background()
+	data := Rollback{Paths: types.ListNull(types.StringType)}
+	testJSONEqual(t, `{"input":{"fixed-number":10011}}`, data.toBody(ctx, 10011))
+	data.Paths = testStringList("/ncs:devices/device{ce0}")
+	data.Selective = types.BoolValue(true)
+	testJSONEqual(t, `{"input":{"fixed-number":10011,"path":["/ncs:devices/device{ce0}"],"selective":[null]}}`, data.toBody(ctx, 10011))
+}
+
+func TestRollbacksModelFromBody(t *testing.T) {
+	tests := []struct {
+		label string
+		want  []int64
+	}{
+		{"", []int64{10012, 10011, 10010}},
+		{"release-1", []int64{10011, 10010}},
+		{"unknown", []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			data := Rollbacks{Label: types.StringNull()}
+			if tt.label != "" {
+				data.Label = types.StringValue(tt.label)
+			}
+			data.fromBody(context.Background(), gjson.Parse(testRollbackFiles))
+			if len(data.Rollbacks) != len(tt.want) {
+				t.Fatalf("got %d rollbacks, want %d", len(data.Rollbacks), len(tt.want))
+			}
+			for i, fixedNumber := range tt.want {
+				if got := data.Rollbacks[i].FixedNumber.ValueInt64(); got != fixedNumber {
+					t.Errorf("rollback %d has fixed number %d, want %d", i, got, fixedNumber)
+				}
+			}
+		})
+	}
+	data := Rollbacks{Label: types.StringNull()}
+	data.fromBody(context.Background(), gjson.Parse(testRollbackFiles))
+	entry := data.Rollbacks[0]
+	if entry.User.ValueString() != "admin" || entry.Timestamp.ValueString() != "2023-05-02 10:00:00" || entry.Via.ValueString() != "rest" || entry.Comment.ValueString() != "second" {
+		t.Errorf("unexpected rollback entry %+v", entry)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+type Service struct {
+	Instance            types.String        `tfsdk:"instance"`
+	Id                  types.String        `tfsdk:"id"`
+	Path                types.String        `tfsdk:"path"`
+	Delete              types.Bool          `tfsdk:"delete"`
+	UndeployOnDestroy   types.Bool          `tfsdk:"undeploy_on_destroy"`
+	Attributes          types.Map           `tfsdk:"attributes"`
+	Lists               []RestconfList      `tfsdk:"lists"`
+	WaitForPlan         *ServiceWaitForPlan `tfsdk:"wait_for_plan"`
+	RedeployTrigger     types.Map           `tfsdk:"redeploy_trigger"`
+	RedeployReconcile   types.Bool          `tfsdk:"redeploy_reconcile"`
+	ModificationsFormat types.String        `tfsdk:"modifications_format"`
+	CheckSync           types.Bool          `tfsdk:"check_sync"`
+	InSync              types.Bool          `tfsdk:"in_sync"`
+	DeviceModifications types.String        `tfsdk:"device_modifications"`
+}
+
+type ServiceWaitForPlan struct {
+	Timeout types.Int64 `tfsdk:"timeout"`
+}
+
+// The service configuration is handled like a nso_restconf resource
+func (data Service) toRestconf() Restconf {
+	return Restconf{
+		Instance:   data.Instance,
+		Id:         data.Id,
+		Path:       data.Path,
+		Delete:     data.Delete,
+		Attributes: data.Attributes,
+		Lists:      data.Lists,
+	}
+}
+
+func (data *Service) fromRestconf(r Restconf) {
+	data.Attributes = r.Attributes
+	data.Lists = r.Lists
+}
+
+func (data Service) getPath() string {
+	return data.Path.ValueString()
+}
+
+func (data Service) getPlanPath() string {
+	return data.Path.ValueString() + "/plan"
+}
+
+// Service actions are invoked by a POST request to the action below the service path
+func (data Service) getActionPath(action string) string {
+	return data.Path.ValueString() + "/" + action
+}
+
+func (data Service) getRedeployBody() string {
+	if data.RedeployReconcile.ValueBool() {
+		return `{"input":{"reconcile":{}}}`
+	}
+	return `{"input":{}}`
+}
+
+func (data Service) getModificationsBody() string {
+	body, _ := sjson.Set(`{"input":{}}`, "input.outformat", data.ModificationsFormat.ValueString())
+	return body
+}
+
+// The output of an action is prefixed with the module of the action
+func actionOutput(res gjson.Result) gjson.Result {
+	return res.Get("*output")
+}
+
+func (data *Service) fromCheckSync(res gjson.Result) {
+	if value := actionOutput(res).Get("in-sync"); value.Exists() {
+		data.InSync = types.BoolValue(value.Bool())
+	} else {
+		data.InSync = types.BoolNull()
+	}
+}
+
+// The modifications in CLI format are returned as a single text, in native format per device
+func (data *Service) fromModifications(res gjson.Result) {
+	output := actionOutput(res)
+	switch data.ModificationsFormat.ValueString() {
+	case "native":
+		devices := make([]string, 0)
+		output.Get("native.device").ForEach(func(_, v gjson.Result) bool {
+			devices = append(devices, fmt.Sprintf("device %s\n%s", v.Get("name").String(), v.Get("data").String()))
+			return true
+		})
+		data.DeviceModifications = types.StringValue(strings.Join(devices, "\n"))
+	default:
+		data.DeviceModifications = types.StringValue(output.Get("cli.local-node.data").String())
+	}
+}
+
+// State of a nano-service plan
+type ServicePlan struct {
+	Components int
+	// states which are not reached yet, as "component/state"
+	Pending []string
+	// states which failed, as "component/state"
+	Failures  []string
+	Failed    bool
+	ErrorInfo string
+}
+
+// The plan container is part of the service module, the prefix of its name is therefore not known upfront
+func parseServicePlan(res gjson.Result) ServicePlan {
+	var plan ServicePlan
+	p := res.Get("*:plan")
+	p.Get("component").ForEach(func(_, component gjson.Result) bool {
+		plan.Components++
+		name := fmt.Sprintf("%s %s", component.Get("type").String(), component.Get("name").String())
+		component.Get("state").ForEach(func(_, state gjson.Result) bool {
+			switch state.Get("status").String() {
+			case "reached":
+			case "failed":
+				plan.Failures = append(plan.Failures, name+"/"+state.Get("name").String())
+			default:
+				plan.Pending = append(plan.Pending, name+"/"+state.Get("name").String())
+			}
+			return true
+		})
+		return true
+	})
+	plan.Failed = p.Get("failed").Exists()
+	errorInfo := make([]string, 0)
+	if message := p.Get("error-info.message"); message.Exists() {
+		errorInfo = append(errorInfo, message.String())
+	}
+	if logEntry := p.Get("error-info.log-entry"); logEntry.Exists() {
+		errorInfo = append(errorInfo, "Log entry: "+logEntry.String())
+	}
+	plan.ErrorInfo = strings.Join(errorInfo, "\n")
+	return plan
+}
+
+func (plan ServicePlan) isReady() bool {
+	return plan.Components > 0 && len(plan.Pending) == 0 && len(plan.Failures) == 0 && !plan.Failed
+}
+
+func (plan ServicePlan) isFailed() bool {
+	return plan.Failed || len(plan.Failures) > 0
+}
@@ -0,0 +1,127 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tidwall/gjson"
+)
+
+func TestServiceModelActionBodies(t *testing.T) {
+	data := Service{Path: types.StringValue("tailf-ncs:services/l3vpn:vpn/l3vpn=a")}
+	if got, want := data.getActionPath("re-deploy"), "tailf-ncs:services/l3vpn:vpn/l3vpn=a/re-deploy"; got != want {
+		t.Errorf("getActionPath() = %q, want %q", got, want)
+	}
+	if got, want := data.getPlanPath(), "tailf-ncs:services/l3vpn:vpn/l3vpn=a/plan"; got != want {
+		t.Errorf("getPlanPath() = %q, want %q", got, want)
+	}
+	testJSONEqual(t, `{"input":{}}`, data.getRedeployBody())
+	data.RedeployReconcile = types.BoolValue(true)
+	testJSONEqual(t, `{"input":{"reconcile":{}}}`, data.getRedeployBody())
+	data.ModificationsFormat = types.StringValue("native")
+	testJSONEqual(t, `{"input":{"outformat":"native"}}`, data.getModificationsBody())
+}
+
+func TestServiceModelCheckSync(t *testing.T) {
+	tests := []struct {
+		body string
+		want types.Bool
+	}{
+		{`{"l3vpn:output":{"in-sync":true}}`, types.BoolValue(true)},
+		{`{"l3vpn:output":{"in-sync":false}}`, types.BoolValue(false)},
+		{`{}`, types.BoolNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			var data Service
+			data.fromCheckSync(gjson.Parse(tt.body))
+			if !data.InSync.Equal(tt.want) {
+				t.Errorf("InSync = %v, want %v", data.InSync, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceModelModifications(t *testing.T) {
+	tests := []struct {
+		format string
+		body   string
+		want   string
+	}{
+		{"cli", `{"l3vpn:output":{"cli":{"local-node":{"data":" devices {\n }"}}}}`, " devices {\n }"},
+		{"native", `{"l3vpn:output":{"native":{"device":[{"name":"ce0","data":"hostname R1"},{"name":"ce1","data":"hostname R2"}]}}}`, "device ce0\nhostname R1\ndevice ce1\nhostname R2"},
+		{"native", `{"l3vpn:output":{}}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			data := Service{ModificationsFormat: types.StringValue(tt.format)}
+			data.fromModifications(gjson.Parse(tt.body))
+			if got := data.DeviceModifications.ValueString(); got != tt.want {
+				t.Errorf("DeviceModifications = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceModelPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      ServicePlan
+		wantReady bool
+	}{
+		{
+			name: "empty",
+			body: `{}`,
+			want: ServicePlan{},
+		},
+		{
+			name:      "ready",
+			body:      `{"l3vpn:plan":{"component":[{"type":"ncs:self","name":"self","state":[{"name":"ncs:init","status":"reached"},{"name":"ncs:ready","status":"reached"}]}]}}`,
+			want:      ServicePlan{Components: 1},
+			wantReady: true,
+		},
+		{
+			name: "pending",
+			body: `{"l3vpn:plan":{"component":[{"type":"ncs:self","name":"self","state":[{"name":"ncs:init","status":"reached"},{"name":"ncs:ready","status":"not-reached"}]}]}}`,
+			want: ServicePlan{Components: 1, Pending: []string{"ncs:self self/ncs:ready"}},
+		},
+		{
+			name: "failed",
+			body: `{"l3vpn:plan":{"component":[{"type":"ncs:self","name":"self","state":[{"name":"ncs:ready","status":"failed"}]}],"failed":[null],"error-info":{"message":"device ce0 is locked","log-entry":"1"}}}`,
+			want: ServicePlan{Components: 1, Failures: []string{"ncs:self self/ncs:ready"}, Failed: true, ErrorInfo: "device ce0 is locked\nLog entry: 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseServicePlan(gjson.Parse(tt.body))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseServicePlan() = %+v, want %+v", got, tt.want)
+			}
+			if got.isReady() != tt.wantReady {
+				t.Errorf("isReady() = %v, want %v", got.isReady(), tt.wantReady)
+			}
+			if got.isFailed() != (tt.want.Failed || len(tt.want.Failures) > 0) {
+				t.Errorf("isFailed() = %v", got.isFailed())
+			}
+		})
+	}
+}
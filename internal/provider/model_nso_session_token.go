@@ -0,0 +1,31 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NSO returns the token of a RESTCONF session in this header if /ncs-config/restconf/token-response/x-auth-token is
+// enabled in ncs.conf, subsequent requests can authenticate with the same header instead of credentials
+const sessionTokenHeader = "X-Auth-Token"
+
+type SessionToken struct {
+	Instance types.String `tfsdk:"instance"`
+	Token    types.String `tfsdk:"token"`
+}
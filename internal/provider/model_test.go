@@ -0,0 +1,295 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Helpers shared by the model unit tests, which check the body builders and parsers of the models without NSO.
+
+// testModelIterations is the number of random models checked by each property-based model test
+const testModelIterations = 50
+
+const testRandomChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_/.: "
+
+func testResourceSchema(r resource.Resource) rschema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+func testDataSourceSchema(d datasource.DataSource) dschema.Schema {
+	var resp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// testRandomModel fills the model with random values for all attributes of the resource schema. Required attributes,
+// like list keys, and booleans are never null, as null booleans are read back as false.
+func testRandomModel(t *testing.T, r *rand.Rand, s rschema.Schema, model interface{}) {
+	t.Helper()
+	objectType := s.Type().(basetypes.ObjectType)
+	value := types.ObjectValueMust(objectType.AttrTypes, testRandomAttributes(r, s.Attributes))
+	if diags := value.As(context.Background(), model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed to convert random model: %v", diags)
+	}
+}
+
+func testRandomAttributes(r *rand.Rand, attributes map[string]rschema.Attribute) map[string]attr.Value {
+	values := make(map[string]attr.Value, len(attributes))
+	// sorted, so that the same seed results in the same model
+	for _, name := range helpers.SortedKeys(attributes) {
+		values[name] = testRandomValue(r, attributes[name])
+	}
+	return values
+}
+
+func testRandomValue(r *rand.Rand, a rschema.Attribute) attr.Value {
+	typ := a.GetType()
+	if a.IsComputed() && !a.IsOptional() && !a.IsRequired() {
+		return testNullValue(typ)
+	}
+	if !a.IsRequired() && typ != types.BoolType && r.Intn(4) == 0 {
+		return testNullValue(typ)
+	}
+	switch n := a.(type) {
+	case rschema.ListNestedAttribute:
+		objectType := n.NestedObject.Type().(basetypes.ObjectType)
+		var elements []attr.Value
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elements = append(elements, types.ObjectValueMust(objectType.AttrTypes, testRandomAttributes(r, n.NestedObject.Attributes)))
+		}
+		return types.ListValueMust(objectType, elements)
+	case rschema.SetNestedAttribute:
+		objectType := n.NestedObject.Type().(basetypes.ObjectType)
+		var elements []attr.Value
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elements = append(elements, types.ObjectValueMust(objectType.AttrTypes, testRandomAttributes(r, n.NestedObject.Attributes)))
+		}
+		return types.SetValueMust(objectType, elements)
+	case rschema.MapNestedAttribute:
+		objectType := n.NestedObject.Type().(basetypes.ObjectType)
+		elements := map[string]attr.Value{}
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elements[testRandomString(r)] = types.ObjectValueMust(objectType.AttrTypes, testRandomAttributes(r, n.NestedObject.Attributes))
+		}
+		return types.MapValueMust(objectType, elements)
+	}
+	return testRandomPrimitive(r, typ)
+}
+
+func testRandomPrimitive(r *rand.Rand, typ attr.Type) attr.Value {
+	switch typ := typ.(type) {
+	case basetypes.ListType:
+		var elements []attr.Value
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elements = append(elements, testRandomPrimitive(r, typ.ElemType))
+		}
+		return types.ListValueMust(typ.ElemType, elements)
+	case basetypes.SetType:
+		var elements []attr.Value
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elements = append(elements, testRandomPrimitive(r, typ.ElemType))
+		}
+		return types.SetValueMust(typ.ElemType, elements)
+	case basetypes.MapType:
+		elements := map[string]attr.Value{}
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elements[testRandomString(r)] = testRandomPrimitive(r, typ.ElemType)
+		}
+		return types.MapValueMust(typ.ElemType, elements)
+	}
+	switch typ {
+	case types.Int64Type:
+		return types.Int64Value(r.Int63n(1<<40) - 1<<39)
+	case types.Float64Type:
+		// values with a single fraction digit are rendered exactly for any number of fraction digits
+		return types.Float64Value(float64(r.Intn(20000)-10000) + 0.5)
+	case types.BoolType:
+		return types.BoolValue(r.Intn(2) == 0)
+	}
+	return types.StringValue(testRandomString(r))
+}
+
+// testRandomString returns a random string including characters which need to be escaped in paths, the numeric
+// suffix makes collisions of list keys and set elements unlikely
+func testRandomString(r *rand.Rand) string {
+	var sb strings.Builder
+	for i := 0; i < 1+r.Intn(10); i++ {
+		sb.WriteByte(testRandomChars[r.Intn(len(testRandomChars))])
+	}
+	sb.WriteString(strconv.Itoa(r.Intn(1000000)))
+	return sb.String()
+}
+
+func testNullValue(typ attr.Type) attr.Value {
+	switch typ := typ.(type) {
+	case basetypes.ObjectType:
+		return types.ObjectNull(typ.AttrTypes)
+	case basetypes.ListType:
+		return types.ListNull(typ.ElemType)
+	case basetypes.SetType:
+		return types.SetNull(typ.ElemType)
+	case basetypes.MapType:
+		return types.MapNull(typ.ElemType)
+	}
+	switch typ {
+	case types.Int64Type:
+		return types.Int64Null()
+	case types.Float64Type:
+		return types.Float64Null()
+	case types.BoolType:
+		return types.BoolNull()
+	}
+	return types.StringNull()
+}
+
+func testModelValue(t *testing.T, model interface{}, typ attr.Type) basetypes.ObjectValue {
+	t.Helper()
+	var value attr.Value
+	if diags := tfsdk.ValueFrom(context.Background(), model, typ, &value); diags.HasError() {
+		t.Fatalf("failed to convert model: %v", diags)
+	}
+	return value.(basetypes.ObjectValue)
+}
+
+// testDataModel initializes a data source model with the attributes identifying the object, which are the required
+// attributes of the data source schema, copied from a resource model
+func testDataModel(t *testing.T, model interface{}, s rschema.Schema, ds dschema.Schema, data interface{}) {
+	t.Helper()
+	source := testModelValue(t, model, s.Type()).Attributes()
+	objectType := ds.Type().(basetypes.ObjectType)
+	values := make(map[string]attr.Value, len(objectType.AttrTypes))
+	for name, typ := range objectType.AttrTypes {
+		if v, ok := source[name]; ok && (ds.Attributes[name].IsRequired() || name == "instance") {
+			values[name] = v
+		} else {
+			values[name] = testNullValue(typ)
+		}
+	}
+	value := types.ObjectValueMust(objectType.AttrTypes, values)
+	if diags := value.As(context.Background(), data, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed to convert data model: %v", diags)
+	}
+}
+
+// testModelDiff compares two models, which can be of different types, by converting them using their schema types.
+// Only attributes contained in both models are compared, ignored attributes are given as attribute names joined by
+// ".", e.g. "interfaces.password".
+func testModelDiff(t *testing.T, want interface{}, wantType attr.Type, got interface{}, gotType attr.Type, ignore ...string) []string {
+	t.Helper()
+	ignored := map[string]bool{}
+	for _, i := range ignore {
+		ignored[i] = true
+	}
+	return testValueDiff("", testModelValue(t, want, wantType), testModelValue(t, got, gotType), ignored)
+}
+
+func testValueDiff(path string, want, got attr.Value, ignored map[string]bool) []string {
+	if ignored[path] {
+		return nil
+	}
+	if want.IsNull() || got.IsNull() {
+		if want.IsNull() != got.IsNull() {
+			return []string{fmt.Sprintf("%s: want %s, got %s", path, want, got)}
+		}
+		return nil
+	}
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+	var diff []string
+	switch w := want.(type) {
+	case basetypes.ObjectValue:
+		g := got.(basetypes.ObjectValue).Attributes()
+		for name, wv := range w.Attributes() {
+			if gv, ok := g[name]; ok {
+				diff = append(diff, testValueDiff(join(name), wv, gv, ignored)...)
+			}
+		}
+		return diff
+	case basetypes.ListValue:
+		return testElementsDiff(path, w.Elements(), got.(basetypes.ListValue).Elements(), ignored)
+	case basetypes.SetValue:
+		return testElementsDiff(path, w.Elements(), got.(basetypes.SetValue).Elements(), ignored)
+	case basetypes.MapValue:
+		g := got.(basetypes.MapValue).Elements()
+		if len(w.Elements()) != len(g) {
+			return []string{fmt.Sprintf("%s: want %s, got %s", path, want, got)}
+		}
+		for key, wv := range w.Elements() {
+			gv, ok := g[key]
+			if !ok {
+				return []string{fmt.Sprintf("%s: missing key %q", path, key)}
+			}
+			diff = append(diff, testValueDiff(path, wv, gv, ignored)...)
+		}
+		return diff
+	}
+	if !want.Equal(got) {
+		return []string{fmt.Sprintf("%s: want %s, got %s", path, want, got)}
+	}
+	return nil
+}
+
+func testElementsDiff(path string, want, got []attr.Value, ignored map[string]bool) []string {
+	if len(want) != len(got) {
+		return []string{fmt.Sprintf("%s: want %d elements, got %d", path, len(want), len(got))}
+	}
+	var diff []string
+	for i := range want {
+		diff = append(diff, testValueDiff(path, want[i], got[i], ignored)...)
+	}
+	return diff
+}
+
+// testJSONEqual compares JSON documents ignoring the order of object members
+func testJSONEqual(t *testing.T, want, got string) {
+	t.Helper()
+	var w, g interface{}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if !reflect.DeepEqual(w, g) {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
@@ -21,11 +21,19 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -44,16 +52,21 @@ type NsoProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+	// wrapTransport allows tests to intercept the HTTP requests of all RESTCONF clients, e.g. to record and replay
+	// them
+	wrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // NsoProviderModel describes the provider data model.
 type NsoProviderModel struct {
-	Username  types.String               `tfsdk:"username"`
-	Password  types.String               `tfsdk:"password"`
-	URL       types.String               `tfsdk:"url"`
-	Insecure  types.Bool                 `tfsdk:"insecure"`
-	Retries   types.Int64                `tfsdk:"retries"`
-	Instances []NsoProviderModelInstance `tfsdk:"instances"`
+	Username    types.String                 `tfsdk:"username"`
+	Password    types.String                 `tfsdk:"password"`
+	URL         types.String                 `tfsdk:"url"`
+	Insecure    types.Bool                   `tfsdk:"insecure"`
+	Retries     types.Int64                  `tfsdk:"retries"`
+	Instances   []NsoProviderModelInstance   `tfsdk:"instances"`
+	CommitQueue *NsoProviderModelCommitQueue `tfsdk:"commit_queue"`
+	AuditLog    *NsoProviderModelAuditLog    `tfsdk:"audit_log"`
 }
 
 type NsoProviderModelInstance struct {
@@ -61,6 +74,18 @@ type NsoProviderModelInstance struct {
 	URL  types.String `tfsdk:"url"`
 }
 
+type NsoProviderModelCommitQueue struct {
+	Mode        types.String `tfsdk:"mode"`
+	ErrorOption types.String `tfsdk:"error_option"`
+	Timeout     types.Int64  `tfsdk:"timeout"`
+}
+
+type NsoProviderModelAuditLog struct {
+	Path            types.String `tfsdk:"path"`
+	Format          types.String `tfsdk:"format"`
+	SensitiveLeaves types.List   `tfsdk:"sensitive_leaves"`
+}
+
 func (p *NsoProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "nso"
 	resp.Version = p.version
@@ -109,6 +134,55 @@ func (p *NsoProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 					},
 				},
 			},
+			"commit_queue": schema.SingleNestedAttribute{
+				MarkdownDescription: "Commit queue settings used for all write operations. If the commit queue is used, either by these settings or by the NSO default, the provider waits until the commit queue items of an operation are completed.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"mode": schema.StringAttribute{
+						MarkdownDescription: helpers.NewAttributeDescription("Commit queue mode, by default the NSO setting is used.").AddStringEnumDescription("async", "sync", "bypass").String,
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("async", "sync", "bypass"),
+						},
+					},
+					"error_option": schema.StringAttribute{
+						MarkdownDescription: helpers.NewAttributeDescription("Action if a commit queue item fails, `rollback-on-error` reverts the configuration changes of the failed item.").AddStringEnumDescription("continue-on-error", "rollback-on-error", "stop-on-error").String,
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("continue-on-error", "rollback-on-error", "stop-on-error"),
+						},
+					},
+					"timeout": schema.Int64Attribute{
+						MarkdownDescription: "Maximum time in seconds to wait for a commit queue item to complete. Defaults to `600`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"audit_log": schema.SingleNestedAttribute{
+				MarkdownDescription: "Write every RESTCONF request and response to a file, including method, path, query parameters, status, duration and bodies. The values of sensitive leaves like passwords are redacted, credentials are never logged.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						MarkdownDescription: "Path of the audit log file. This can also be set as the NSO_AUDIT_LOG environment variable.",
+						Optional:            true,
+					},
+					"format": schema.StringAttribute{
+						MarkdownDescription: helpers.NewAttributeDescription("Format of the audit log, `ndjson` appends one JSON object per request to the file, `har` truncates the file when the provider process starts and writes an HTTP archive, so it only keeps the requests of the last provider process, e.g. of the apply, and the requests of the plan are lost. Provider configurations using the same file, e.g. aliased providers, share the log. This can also be set as the NSO_AUDIT_LOG_FORMAT environment variable.").AddStringEnumDescription(helpers.AuditLogFormatNDJSON, helpers.AuditLogFormatHAR).AddDefaultValueDescription(helpers.AuditLogFormatNDJSON).String,
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(helpers.AuditLogFormatNDJSON, helpers.AuditLogFormatHAR),
+						},
+					},
+					"sensitive_leaves": schema.ListAttribute{
+						MarkdownDescription: "Names of additional leaves whose values are redacted. Leaves with names containing `password`, `passphrase`, `secret` or `private-key` are always redacted.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -245,6 +319,44 @@ func (p *NsoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		retries = config.Retries.ValueInt64()
 	}
 
+	var commitQueueMode, commitQueueErrorOption string
+	commitQueueTimeout := helpers.DefaultCommitQueueTimeout
+	if config.CommitQueue != nil {
+		commitQueueMode = config.CommitQueue.Mode.ValueString()
+		commitQueueErrorOption = config.CommitQueue.ErrorOption.ValueString()
+		if !config.CommitQueue.Timeout.IsNull() {
+			commitQueueTimeout = time.Duration(config.CommitQueue.Timeout.ValueInt64()) * time.Second
+		}
+	}
+
+	auditLogPath := os.Getenv("NSO_AUDIT_LOG")
+	auditLogFormat := os.Getenv("NSO_AUDIT_LOG_FORMAT")
+	var auditLogSensitiveLeaves []string
+	if config.AuditLog != nil {
+		if !config.AuditLog.Path.IsNull() {
+			auditLogPath = config.AuditLog.Path.ValueString()
+		}
+		if !config.AuditLog.Format.IsNull() {
+			auditLogFormat = config.AuditLog.Format.ValueString()
+		}
+		config.AuditLog.SensitiveLeaves.ElementsAs(ctx, &auditLogSensitiveLeaves, false)
+	}
+	if auditLogFormat == "" {
+		auditLogFormat = helpers.AuditLogFormatNDJSON
+	}
+	var auditLog *helpers.AuditLog
+	if auditLogPath != "" {
+		var err error
+		auditLog, err = helpers.NewAuditLog(auditLogPath, auditLogFormat, p.version, auditLogSensitiveLeaves)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create audit log",
+				"Unable to create audit log:\n\n"+err.Error(),
+			)
+			return
+		}
+	}
+
 	clients := make(map[string]*restconf.Client)
 	c, err := restconf.NewClient(url, username, password, insecure, restconf.MaxRetries(int(retries)), restconf.SkipDiscovery("/restconf", true))
 	if err != nil {
@@ -254,6 +366,13 @@ func (p *NsoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 		return
 	}
+	if p.wrapTransport != nil {
+		c.HttpClient.Transport = p.wrapTransport(c.HttpClient.Transport)
+	}
+	if auditLog != nil {
+		helpers.ConfigureAuditLog(c, "", auditLog)
+	}
+	helpers.ConfigureCommitQueue(c, commitQueueMode, commitQueueErrorOption, commitQueueTimeout)
 	clients[""] = c
 
 	for _, instance := range config.Instances {
@@ -265,19 +384,32 @@ func (p *NsoProvider) Configure(ctx context.Context, req provider.ConfigureReque
 			)
 			return
 		}
+		if p.wrapTransport != nil {
+			c.HttpClient.Transport = p.wrapTransport(c.HttpClient.Transport)
+		}
+		if auditLog != nil {
+			helpers.ConfigureAuditLog(c, instance.Name.ValueString(), auditLog)
+		}
+		helpers.ConfigureCommitQueue(c, commitQueueMode, commitQueueErrorOption, commitQueueTimeout)
 		clients[instance.Name.ValueString()] = c
 	}
 
 	resp.DataSourceData = clients
 	resp.ResourceData = clients
+	resp.ActionData = clients
+	resp.ListResourceData = clients
+	resp.EphemeralResourceData = clients
 }
 
 func (p *NsoProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewRestconfResource,
 		NewDeviceConfigResource,
+		NewServiceResource,
+		NewRollbackResource,
 		NewDeviceResource,
 		NewDeviceGroupResource,
+		NewIOSInterfaceGigabitEthernetResource,
 	}
 }
 
@@ -285,8 +417,48 @@ func (p *NsoProvider) DataSources(ctx context.Context) []func() datasource.DataS
 	return []func() datasource.DataSource{
 		NewRestconfDataSource,
 		NewDeviceConfigDataSource,
+		NewRollbacksDataSource,
+		NewCommitQueueDataSource,
 		NewDeviceDataSource,
+		NewDevicesDataSource,
 		NewDeviceGroupDataSource,
+		NewDeviceGroupsDataSource,
+		NewIOSInterfaceGigabitEthernetDataSource,
+	}
+}
+
+func (p *NsoProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewRestconfEphemeralResource,
+		NewSessionTokenEphemeralResource,
+	}
+}
+
+func (p *NsoProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewDeviceSyncFromAction,
+		NewDeviceSyncToAction,
+		NewDeviceCompareConfigAction,
+		NewDeviceConnectAction,
+		NewServiceRedeployAction,
+		NewPackagesReloadAction,
+	}
+}
+
+func (p *NsoProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRestconfPathFunction,
+		NewEncodeKeyFunction,
+		NewDeviceConfigPathFunction,
+		NewXPathToRestconfFunction,
+	}
+}
+
+func (p *NsoProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewDeviceConfigListResource,
+		NewDeviceListResource,
+		NewDeviceGroupListResource,
 	}
 }
 
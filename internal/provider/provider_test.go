@@ -18,19 +18,122 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/cassette"
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
+// testAccActionVersionChecks skips tests of actions, which are supported by Terraform 1.14 and later
+var testAccActionVersionChecks = []tfversion.TerraformVersionCheck{
+	tfversion.SkipBelow(version.Must(version.NewVersion("1.14.0"))),
+}
+
+// testAccFunctionVersionChecks skips tests of provider functions, which are supported by Terraform 1.8 and later
+var testAccFunctionVersionChecks = []tfversion.TerraformVersionCheck{
+	tfversion.SkipBelow(tfversion.Version1_8_0),
+}
+
+// testAccIdentityVersionChecks skips tests of imports with the identity attribute, which are supported by Terraform 1.12
+// and later
+var testAccIdentityVersionChecks = []tfversion.TerraformVersionCheck{
+	tfversion.SkipBelow(tfversion.Version1_12_0),
+}
+
+// testAccEphemeralVersionChecks skips tests of ephemeral resources, which are supported by Terraform 1.10 and later
+var testAccEphemeralVersionChecks = []tfversion.TerraformVersionCheck{
+	tfversion.SkipBelow(tfversion.Version1_10_0),
+}
+
+// testAccCassetteDir holds the recorded RESTCONF exchanges of the acceptance tests, one cassette file per test
+const testAccCassetteDir = "testdata/cassettes"
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
-var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"nso": providerserver.NewProtocol6WithError(New("test")()),
+//
+// If NSO_CASSETTE is set to "record", the RESTCONF exchanges of the test are
+// recorded to a cassette file with credentials redacted. If it is set to
+// "replay", the test runs against the recorded exchanges without NSO and is
+// skipped if no cassette exists.
+func testAccProtoV6ProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	p := New("test")().(*NsoProvider)
+	if mode := os.Getenv("NSO_CASSETTE"); mode != "" {
+		recorder := testAccRecorder(t, cassette.Mode(mode))
+		p.wrapTransport = func(next http.RoundTripper) http.RoundTripper {
+			return recorder.Transport(next)
+		}
+	}
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"nso": providerserver.NewProtocol6WithError(p),
+	}
+}
+
+// testAccEphemeralProviderFactories adds the echo provider, which stores the result of an ephemeral resource in the
+// state of its echo resource, so it can be checked
+func testAccEphemeralProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	factories := testAccProtoV6ProviderFactories(t)
+	factories["echo"] = echoprovider.NewProviderServer()
+	return factories
+}
+
+func testAccRecorder(t *testing.T, mode cassette.Mode) *cassette.Recorder {
+	t.Helper()
+	path := filepath.Join(testAccCassetteDir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	recorder, err := cassette.New(mode, path, os.Getenv("NSO_PASSWORD"))
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("No cassette recorded for %s", t.Name())
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if t.Failed() {
+			return
+		}
+		if err := recorder.Save(); err != nil {
+			t.Errorf("Failed to save cassette: %s", err)
+		}
+	})
+	return recorder
+}
+
+// testAccMockServer is the in-process NSO stand-in the acceptance tests run against if NSO_URL is not set.
+var testAccMockServer *nsomock.Server
+
+func TestMain(m *testing.M) {
+	// Replayed tests do not connect to NSO, but the provider requires a URL and credentials
+	if os.Getenv("NSO_CASSETTE") == string(cassette.ModeReplay) && os.Getenv("NSO_URL") == "" {
+		os.Setenv("NSO_URL", "https://nso.invalid")
+		os.Setenv("NSO_USERNAME", "admin")
+		os.Setenv("NSO_PASSWORD", cassette.Redacted)
+	}
+	if os.Getenv("TF_ACC") != "" && os.Getenv("NSO_URL") == "" {
+		testAccMockServer = nsomock.NewServer()
+		os.Setenv("NSO_URL", testAccMockServer.URL)
+		os.Setenv("NSO_USERNAME", testAccMockServer.Username)
+		os.Setenv("NSO_PASSWORD", testAccMockServer.Password)
+	}
+	code := m.Run()
+	if testAccMockServer != nil {
+		testAccMockServer.Close()
+	}
+	os.Exit(code)
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -47,3 +150,294 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("NSO_URL env variable must be set for acceptance tests")
 	}
 }
+
+// testAccLivePreCheck skips tests which depend on NSO behavior the mock server does not implement, like rollback
+// files.
+func testAccLivePreCheck(t *testing.T) {
+	testAccPreCheck(t)
+	if testAccMockServer != nil {
+		t.Skip("Test requires a live NSO instance, set NSO_URL to run it")
+	}
+}
+
+// TestProviderSchema validates the schemas of the provider, its resources, data sources, actions and ephemeral resources
+func TestProviderSchema(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+	for _, name := range []string{"restconf_path", "encode_key", "device_config_path", "xpath_to_restconf"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Errorf("missing function %s", name)
+		}
+	}
+	for _, name := range []string{"nso_device", "nso_device_group", "nso_device_config"} {
+		if _, ok := resp.ListResourceSchemas[name]; !ok {
+			t.Errorf("missing list resource %s", name)
+		}
+	}
+	for _, name := range []string{"nso_device_sync_from", "nso_device_sync_to", "nso_device_compare_config", "nso_device_connect", "nso_service_redeploy", "nso_packages_reload"} {
+		if _, ok := resp.ActionSchemas[name]; !ok {
+			t.Errorf("missing action %s", name)
+		}
+	}
+	for _, name := range []string{"nso_restconf", "nso_session_token"} {
+		if _, ok := resp.EphemeralResourceSchemas[name]; !ok {
+			t.Errorf("missing ephemeral resource %s", name)
+		}
+	}
+}
+
+// testListResult is a list resource result with the identity and resource values decoded
+type testListResult struct {
+	DisplayName string
+	Identity    map[string]tftypes.Value
+	Resource    map[string]tftypes.Value
+}
+
+// testListResource evaluates a list block against the mock server like "terraform query" does, the attributes missing
+// in config are null
+func testListResource(t *testing.T, s *nsomock.Server, typeName string, config map[string]tftypes.Value) []testListResult {
+	t.Helper()
+	results, errs := testListResourceErrors(t, s, typeName, config)
+	for _, err := range errs {
+		t.Error(err)
+	}
+	if len(errs) > 0 {
+		t.FailNow()
+	}
+	return results
+}
+
+// testListResourceErrors evaluates a list block and returns the error diagnostics of the results instead of failing
+func testListResourceErrors(t *testing.T, s *nsomock.Server, typeName string, config map[string]tftypes.Value) ([]testListResult, []string) {
+	t.Helper()
+	ctx := context.Background()
+	server, schemas := testConfigureProvider(t, s)
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := server.(tfprotov6.ListResourceServer).ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        typeName,
+		Config:          testDynamicValue(t, schemas.ListResourceSchemas[typeName].ValueType(), config),
+		IncludeResource: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []testListResult
+	var errs []string
+	for result := range stream.Results {
+		if e := testProtoErrors(result.Diagnostics); len(e) > 0 {
+			errs = append(errs, e...)
+			continue
+		}
+		results = append(results, testListResult{
+			DisplayName: result.DisplayName,
+			Identity:    testDynamicValueAttributes(t, result.Identity.IdentityData, identitySchemas.IdentitySchemas[typeName].ValueType()),
+			Resource:    testDynamicValueAttributes(t, result.Resource, schemas.ResourceSchemas[typeName].ValueType()),
+		})
+	}
+	return results, errs
+}
+
+func testProtoErrors(diags []*tfprotov6.Diagnostic) []string {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, d.Summary+": "+d.Detail)
+		}
+	}
+	return errs
+}
+
+func testDynamicValue(t *testing.T, typ tftypes.Type, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	object := map[string]tftypes.Value{}
+	for name, attributeType := range typ.(tftypes.Object).AttributeTypes {
+		if v, ok := values[name]; ok {
+			object[name] = v
+		} else {
+			object[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, object))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+func testDynamicValueAttributes(t *testing.T, dv *tfprotov6.DynamicValue, typ tftypes.Type) map[string]tftypes.Value {
+	t.Helper()
+	v, err := dv.Unmarshal(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := map[string]tftypes.Value{}
+	if err := v.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes
+}
+
+// testString returns the value of a string attribute, "<null>" if the attribute is null
+func testString(t *testing.T, v tftypes.Value) string {
+	t.Helper()
+	if v.IsNull() {
+		return "<null>"
+	}
+	var s string
+	if err := v.As(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// testImportResourceState imports a resource like an import block with the identity attribute does and returns the
+// attributes of the imported state
+func testImportResourceState(t *testing.T, typeName string, identity map[string]tftypes.Value) map[string]tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		Identity: &tfprotov6.ResourceIdentityData{
+			IdentityData: testDynamicValue(t, identitySchemas.IdentitySchemas[typeName].ValueType(), identity),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := testProtoErrors(resp.Diagnostics); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if len(resp.ImportedResources) != 1 {
+		t.Fatalf("%s: want 1 imported resource, got %d", typeName, len(resp.ImportedResources))
+	}
+	return testDynamicValueAttributes(t, resp.ImportedResources[0].State, schemas.ResourceSchemas[typeName].ValueType())
+}
+
+// testUpgradeResourceState upgrades a raw JSON state of the given schema version and returns the attributes of the
+// upgraded state
+func testUpgradeResourceState(t *testing.T, typeName string, version int64, state string) map[string]tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := testProtoErrors(resp.Diagnostics); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return testDynamicValueAttributes(t, resp.UpgradedState, schemas.ResourceSchemas[typeName].ValueType())
+}
+
+// testConfigureProvider returns a provider server configured to use the mock server and its schemas
+func testConfigureProvider(t *testing.T, s *nsomock.Server) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	t.Setenv("NSO_URL", s.URL)
+	t.Setenv("NSO_USERNAME", s.Username)
+	t.Setenv("NSO_PASSWORD", s.Password)
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: testDynamicValue(t, schemas.Provider.ValueType(), nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := testProtoErrors(configureResp.Diagnostics); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return server, schemas
+}
+
+// testOpenEphemeralResource opens an ephemeral resource against the mock server and returns the attributes of the
+// result and the error diagnostics, the attributes missing in config are null
+func testOpenEphemeralResource(t *testing.T, s *nsomock.Server, typeName string, config map[string]tftypes.Value) (map[string]tftypes.Value, []string) {
+	t.Helper()
+	ctx := context.Background()
+	server, schemas := testConfigureProvider(t, s)
+
+	typ := schemas.EphemeralResourceSchemas[typeName].ValueType()
+	resp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config:   testDynamicValue(t, typ, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := testProtoErrors(resp.Diagnostics); len(errs) > 0 {
+		return nil, errs
+	}
+	return testDynamicValueAttributes(t, resp.Result, typ), nil
+}
+
+// testEphemeralLogs writes the audit log of the provider to a temporary file and captures the debug log of the RESTCONF
+// client, it returns a function checking that neither contains any of the secrets
+func testEphemeralLogs(t *testing.T) func(secrets ...string) string {
+	t.Helper()
+	auditLog := filepath.Join(t.TempDir(), "audit.ndjson")
+	t.Setenv("NSO_AUDIT_LOG", auditLog)
+	var debugLog bytes.Buffer
+	log.SetOutput(&debugLog)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	return func(secrets ...string) string {
+		t.Helper()
+		content, err := os.ReadFile(auditLog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range secrets {
+			if strings.Contains(string(content), secret) {
+				t.Errorf("audit log: contains secret %q: %s", secret, content)
+			}
+			if strings.Contains(debugLog.String(), secret) {
+				t.Errorf("debug log: contains secret %q: %s", secret, debugLog.String())
+			}
+		}
+		return string(content)
+	}
+}
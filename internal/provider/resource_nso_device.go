@@ -22,21 +22,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/netascode/go-restconf"
+	"github.com/tidwall/gjson"
 )
 
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.ResourceWithIdentity = &DeviceResource{}
+var _ resource.ResourceWithMoveState = &DeviceResource{}
+
 func NewDeviceResource() resource.Resource {
 	return &DeviceResource{}
 }
@@ -84,28 +92,75 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					int64validator.Between(0, 65535),
 				},
 			},
+			"connect_timeout": schema.Int64Attribute{
+				MarkdownDescription: helpers.NewAttributeDescription("Timeout in seconds for new connections. If this leaf is not configured, the value from the global settings is used.").AddIntegerRangeDescription(1, 4294967295).String,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4294967295),
+				},
+			},
+			"read_timeout": schema.Int64Attribute{
+				MarkdownDescription: helpers.NewAttributeDescription("Timeout in seconds used when reading data. If this leaf is not configured, the value from the global settings is used.").AddIntegerRangeDescription(1, 4294967295).String,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4294967295),
+				},
+			},
+			"write_timeout": schema.Int64Attribute{
+				MarkdownDescription: helpers.NewAttributeDescription("Timeout in seconds used when writing data. If this leaf is not configured, the value from the global settings is used.").AddIntegerRangeDescription(1, 4294967295).String,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4294967295),
+				},
+			},
 			"authgroup": schema.StringAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("The authentication credentials used when connecting to this managed device.").String,
+				MarkdownDescription: helpers.NewAttributeDescription("The authentication credentials used when connecting to this managed device.").AddReferenceDescription("tailf-ncs:devices/authgroups/group", "").String,
 				Optional:            true,
 			},
 			"admin_state": schema.StringAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("Administrative state.").AddStringEnumDescription("locked", "unlocked", "southbound-locked", "config-locked", "call-home").String,
+				MarkdownDescription: helpers.NewAttributeDescription("Administrative state.").AddStringEnumDescription("locked", "unlocked", "southbound-locked", "config-locked", "call-home").AddDefaultValueDescription("locked").String,
 				Optional:            true,
+				Computed:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("locked", "unlocked", "southbound-locked", "config-locked", "call-home"),
 				},
+				Default: stringdefault.StaticString("locked"),
 			},
 			"netconf_net_id": schema.StringAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("NETCONF NED ID.").String,
+				MarkdownDescription: helpers.NewAttributeDescription("NETCONF NED ID.").AddConflictsWithDescription("cli_ned_id", "generic_ned_id").String,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("cli_ned_id"), path.MatchRelative().AtParent().AtName("generic_ned_id")),
+				},
 			},
 			"cli_ned_id": schema.StringAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("CLI NED ID.").String,
+				MarkdownDescription: helpers.NewAttributeDescription("CLI NED ID.").AddConflictsWithDescription("netconf_net_id", "generic_ned_id").String,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("netconf_net_id"), path.MatchRelative().AtParent().AtName("generic_ned_id")),
+				},
 			},
 			"generic_ned_id": schema.StringAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("Generic NED ID.").String,
+				MarkdownDescription: helpers.NewAttributeDescription("Generic NED ID.").AddConflictsWithDescription("netconf_net_id", "cli_ned_id").String,
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("netconf_net_id"), path.MatchRelative().AtParent().AtName("cli_ned_id")),
+				},
+			},
+		},
+	}
+}
+
+func (r *DeviceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"instance": identityschema.StringAttribute{
+				Description:       "An instance name from the provider configuration.",
+				OptionalForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "A string uniquely identifying the managed device.",
+				RequiredForImport: true,
 			},
 		},
 	}
@@ -119,6 +174,36 @@ func (r *DeviceResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.clients = req.ProviderData.(map[string]*restconf.Client)
 }
 
+// Check that entries referenced by leafrefs exist before anything is committed
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state Device
+
+	// Read plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Instance.IsUnknown() {
+		return
+	}
+
+	// Read state
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := r.clients[plan.Instance.ValueString()]
+	if req.State.Raw.IsNull() || !plan.Authgroup.Equal(state.Authgroup) {
+		resp.Diagnostics.Append(helpers.CheckReferences(ctx, client, path.Root("authgroup"), plan.Authgroup, "tailf-ncs:devices/authgroups/group=%v")...)
+	}
+}
+
 func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan Device
 
@@ -142,12 +227,15 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	emptyLeafsDelete := plan.getEmptyLeafsDelete(ctx)
 	tflog.Debug(ctx, fmt.Sprintf("List of empty leafs to delete: %+v", emptyLeafsDelete))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+
 	if YangPatch {
 		edits := []restconf.YangPatchEdit{restconf.NewYangPatchEdit("merge", plan.getPath(), restconf.Body{Str: body})}
 		for _, i := range emptyLeafsDelete {
 			edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 		}
-		_, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		res, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object, got error: %s", err))
 			return
@@ -155,14 +243,16 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	} else {
 		res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 		if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-			_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+			res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 		}
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 			return
 		}
 		for _, i := range emptyLeafsDelete {
 			res, err := r.clients[plan.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -172,10 +262,19 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	plan.Id = types.StringValue(plan.getPath())
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
 }
 
 func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -211,6 +310,9 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, state.getIdentity())
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -245,6 +347,8 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	emptyLeafsDelete := plan.getEmptyLeafsDelete(ctx)
 	tflog.Debug(ctx, fmt.Sprintf("List of empty leafs to delete: %+v", emptyLeafsDelete))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+
 	if YangPatch {
 		edits := []restconf.YangPatchEdit{restconf.NewYangPatchEdit("merge", plan.getPath(), restconf.Body{Str: body})}
 		for _, i := range deletedListItems {
@@ -253,7 +357,8 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		for _, i := range emptyLeafsDelete {
 			edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 		}
-		_, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		res, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update object, got error: %s", err))
 			return
@@ -261,14 +366,16 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	} else {
 		res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 		if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-			_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+			res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 		}
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 			return
 		}
 		for _, i := range deletedListItems {
 			res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -276,6 +383,7 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 		for _, i := range emptyLeafsDelete {
 			res, err := r.clients[plan.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -283,10 +391,19 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.Id.ValueString()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.Id.ValueString()))
 }
 
 func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -307,8 +424,10 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Delete", state.Id.ValueString()))
 	deleteMode := "all"
 
+	commitQueue := helpers.NewCommitQueue(r.clients[state.Instance.ValueString()])
 	if deleteMode == "all" {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(state.Id.ValueString())
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 && res.StatusCode != 400 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
@@ -322,7 +441,8 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 			for _, i := range deletePaths {
 				edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 			}
-			_, err := r.clients[state.Instance.ValueString()].YangPatchData("", "1", "", edits)
+			res, err := r.clients[state.Instance.ValueString()].YangPatchData("", "1", "", edits)
+			commitQueue.Add(res)
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -330,6 +450,7 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		} else {
 			for _, i := range deletePaths {
 				res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+				commitQueue.Add(res)
 				if err != nil && res.StatusCode != 404 {
 					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 					return
@@ -337,6 +458,10 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 			}
 		}
 	}
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Delete finished successfully", state.Id.ValueString()))
 
@@ -344,5 +469,78 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	var identity DeviceIdentity
+
+	// Read identity
+	diags := req.Identity.Get(ctx, &identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := identity.toResource()
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Import", state.getPath()))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), state.getPath())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), state.Instance)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), state.Name)...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", state.getPath()))
+}
+
+func (r *DeviceResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveRestconfState},
+	}
+}
+
+// An nso_restconf resource managing the same object can be migrated to this resource using a moved block
+func (r *DeviceResource) moveRestconfState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "nso_restconf" || !strings.HasSuffix(strings.ToLower(req.SourceProviderAddress), "/ciscodevnet/nso") {
+		return
+	}
+
+	source, diags := getMovedRestconf(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if helpers.PathKeys("tailf-ncs:devices/device=%v", source.Path.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Move Resource State", fmt.Sprintf("The path '%s' of the nso_restconf resource is not a path of the nso_device resource.", source.Path.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Move", source.Path.ValueString()))
+
+	identity := Device{Instance: source.Instance, Id: source.Path}.getIdentity()
+	state := identity.toResource()
+
+	var data DeviceData
+	data.fromBody(ctx, gjson.Parse(source.toBody(ctx)))
+	state.Address = data.Address
+	state.Port = data.Port
+	state.ConnectTimeout = data.ConnectTimeout
+	state.ReadTimeout = data.ReadTimeout
+	state.WriteTimeout = data.WriteTimeout
+	state.Authgroup = data.Authgroup
+	state.AdminState = data.AdminState
+	state.NetconfNetId = data.NetconfNetId
+	state.CliNedId = data.CliNedId
+	state.GenericNedId = data.GenericNedId
+	state.Id = types.StringValue(state.getPath())
+
+	diags = resp.TargetState.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.TargetIdentity.Set(ctx, identity)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Move finished successfully", state.getPath()))
 }
@@ -20,13 +20,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/netascode/go-restconf"
@@ -35,6 +41,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &DeviceConfigResource{}
 var _ resource.ResourceWithImportState = &DeviceConfigResource{}
+var _ resource.ResourceWithModifyPlan = &DeviceConfigResource{}
+var _ resource.ResourceWithIdentity = &DeviceConfigResource{}
+var _ resource.ResourceWithUpgradeState = &DeviceConfigResource{}
 
 func NewDeviceConfigResource() resource.Resource {
 	return &DeviceConfigResource{}
@@ -51,7 +60,8 @@ func (r *DeviceConfigResource) Metadata(ctx context.Context, req resource.Metada
 func (r *DeviceConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Manages a config part of an NSO device.",
+		MarkdownDescription: "Manages a config part of an NSO device. The sync state of the device is exposed as `device_in_sync` and can be checked before applying changes using `out_of_sync_behaviour`.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"instance": schema.StringAttribute{
@@ -85,6 +95,17 @@ func (r *DeviceConfigResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 			},
+			"out_of_sync_behaviour": schema.StringAttribute{
+				MarkdownDescription: helpers.NewAttributeDescription("Run `check-sync` before applying changes and handle an out-of-sync device: `fail` results in an error, `sync_from` runs `sync-from` to accept the device configuration and `warn` applies the changes anyway, which might overwrite manual changes at the device. If not set, changes are applied without checking the sync state.").AddStringEnumDescription("fail", "sync_from", "warn").String,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("fail", "sync_from", "warn"),
+				},
+			},
+			"device_in_sync": schema.BoolAttribute{
+				MarkdownDescription: "Result of the `check-sync` action of the device, `false` if the device configuration was changed outside of NSO. Only read if `out_of_sync_behaviour` is set, unknown if the sync state cannot be determined.",
+				Computed:            true,
+			},
 			"attributes": schema.MapAttribute{
 				MarkdownDescription: "Map of key-value pairs which represents the YANG leafs and its values.",
 				Optional:            true,
@@ -121,6 +142,25 @@ func (r *DeviceConfigResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+func (r *DeviceConfigResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"instance": identityschema.StringAttribute{
+				Description:       "An instance name from the provider configuration.",
+				OptionalForImport: true,
+			},
+			"device": identityschema.StringAttribute{
+				Description:       "An NSO device name.",
+				RequiredForImport: true,
+			},
+			"path": identityschema.StringAttribute{
+				Description:       "A RESTCONF path.",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
 func (r *DeviceConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -146,11 +186,18 @@ func (r *DeviceConfigResource) Create(ctx context.Context, req resource.CreateRe
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Create", plan.getPath()))
 
+	resp.Diagnostics.Append(r.ensureInSync(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
 	body := plan.toBody(ctx)
 	res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 	if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-		_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+		res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 	}
+	commitQueue.Add(res)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 		return
@@ -162,6 +209,21 @@ func (r *DeviceConfigResource) Create(ctx context.Context, req resource.CreateRe
 		plan.Attributes = types.MapNull(types.StringType)
 	}
 
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
+	plan.DeviceInSync = types.BoolNull()
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readDeviceInSync(ctx, &plan)...)
+
 	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
 
 	diags = resp.State.Set(ctx, &plan)
@@ -198,10 +260,15 @@ func (r *DeviceConfigResource) Read(ctx context.Context, req resource.ReadReques
 		state.fromBody(ctx, res.Res)
 	}
 
+	resp.Diagnostics.Append(r.readDeviceInSync(ctx, &state)...)
+
 	tflog.Debug(ctx, fmt.Sprintf("%s: Read finished successfully", state.getPath()))
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, state.getIdentity())
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *DeviceConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -228,11 +295,18 @@ func (r *DeviceConfigResource) Update(ctx context.Context, req resource.UpdateRe
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Update", plan.getPath()))
 
+	resp.Diagnostics.Append(r.ensureInSync(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
 	body := plan.toBody(ctx)
 	res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 	if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-		_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+		res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 	}
+	commitQueue.Add(res)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 		return
@@ -243,12 +317,28 @@ func (r *DeviceConfigResource) Update(ctx context.Context, req resource.UpdateRe
 
 	for _, i := range deletedListItems {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
 		}
 	}
 
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
+	plan.DeviceInSync = types.BoolNull()
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readDeviceInSync(ctx, &plan)...)
+
 	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.getPath()))
 
 	diags = resp.State.Set(ctx, &plan)
@@ -272,26 +362,177 @@ func (r *DeviceConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Delete", state.getPath()))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[state.Instance.ValueString()])
 	if state.Delete.ValueBool() {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(state.getPath())
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
 		}
 	}
 
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("%s: Delete finished successfully", state.getPath()))
 
 	resp.State.RemoveResource(ctx)
 }
 
 func (r *DeviceConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		r.importStateIdentity(ctx, req, resp)
+		return
+	}
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Import", req.ID))
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), req.ID)...)
+	// The RESTCONF path of the device config, e.g. "tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:hostname",
+	// is split into device and path, other ids are used as path as before
+	if device, configPath, ok := splitDeviceConfigPath(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("device"), device)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), configPath)...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), req.ID)...)
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete"), true)...)
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", req.ID))
 }
+
+// splitDeviceConfigPath returns the device and the path below the config of a RESTCONF path built by DeviceConfigPath,
+// the path is null if the path refers to the whole config
+func splitDeviceConfigPath(p string) (types.String, types.String, bool) {
+	if keys := helpers.PathKeys("tailf-ncs:devices/device=%v/config", p); keys != nil {
+		return types.StringValue(keys[0]), types.StringNull(), true
+	}
+	i := strings.Index(p, "/config/")
+	if i < 0 {
+		return types.StringNull(), types.StringNull(), false
+	}
+	keys := helpers.PathKeys("tailf-ncs:devices/device=%v", p[:i])
+	if keys == nil || p[i+len("/config/"):] == "" {
+		return types.StringNull(), types.StringNull(), false
+	}
+	return types.StringValue(keys[0]), types.StringValue(p[i+len("/config/"):]), true
+}
+
+// Schema version 0 covers the states of 0.1.x, with list items holding an attributes map, as well as the states of
+// 0.2.x
+func (r *DeviceConfigResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeListItemsState},
+	}
+}
+
+// Import using the identity attribute of an import block, which identifies the device and path separately
+func (r *DeviceConfigResource) importStateIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity DeviceConfigIdentity
+
+	// Read identity
+	diags := req.Identity.Get(ctx, &identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := DeviceConfig{
+		Instance: identity.Instance,
+		Device:   identity.Device,
+		Path:     identity.Path,
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Import", state.getPath()))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), state.getPath())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), state.Instance)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("device"), state.Device)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), state.Path)...)
+	// The delete attribute is not part of the device configuration, it is set to its default to avoid a plan
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete"), true)...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", state.getPath()))
+}
+
+func (r *DeviceConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state DeviceConfig
+
+	// Read state
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeviceInSync.IsNull() && !state.DeviceInSync.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("device_in_sync"), "Device out of sync", fmt.Sprintf("The configuration of device '%s' was changed outside of NSO. Use 'out_of_sync_behaviour' to define how changes are applied to an out-of-sync device.", state.Device.ValueString()))
+	}
+}
+
+// Run check-sync before writing to the device and handle an out-of-sync device according to out_of_sync_behaviour
+func (r *DeviceConfigResource) ensureInSync(ctx context.Context, data DeviceConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.OutOfSyncBehaviour.IsNull() {
+		return diags
+	}
+	client := r.clients[data.Instance.ValueString()]
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Checking device sync", data.getDeviceActionPath("check-sync")))
+	res, err := client.PostData(data.getDeviceActionPath("check-sync"), `{"input":{}}`)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to check sync of device '%s', got error: %s", data.Device.ValueString(), err))
+		return diags
+	}
+	result := actionOutput(res.Res).Get("result").String()
+	if result == "in-sync" {
+		return diags
+	}
+	if result != "out-of-sync" {
+		diags.AddWarning("Device sync state unknown", fmt.Sprintf("The check-sync action of device '%s' returned '%s', changes are applied without knowing the sync state.", data.Device.ValueString(), result))
+		return diags
+	}
+
+	switch data.OutOfSyncBehaviour.ValueString() {
+	case "sync_from":
+		tflog.Debug(ctx, fmt.Sprintf("%s: Synchronizing from device", data.getDeviceActionPath("sync-from")))
+		res, err := client.PostData(data.getDeviceActionPath("sync-from"), `{"input":{}}`)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Failed to sync from device '%s', got error: %s", data.Device.ValueString(), err))
+		} else if output := actionOutput(res.Res); !output.Get("result").Bool() {
+			diags.AddError("Failed to sync from device", fmt.Sprintf("The sync-from action of device '%s' failed: %s", data.Device.ValueString(), output.Get("info").String()))
+		}
+	case "warn":
+		diags.AddAttributeWarning(path.Root("out_of_sync_behaviour"), "Device out of sync", fmt.Sprintf("The configuration of device '%s' was changed outside of NSO. The changes are applied anyway, which might overwrite the manual changes or fail depending on the NSO out-of-sync settings.", data.Device.ValueString()))
+	default:
+		diags.AddAttributeError(path.Root("out_of_sync_behaviour"), "Device out of sync", fmt.Sprintf("The configuration of device '%s' was changed outside of NSO. Synchronize the device using sync-from or sync-to, or set 'out_of_sync_behaviour' to 'sync_from'.", data.Device.ValueString()))
+	}
+	return diags
+}
+
+// Read the sync state of the device if out_of_sync_behaviour is set, failures are reported as warnings as they do
+// not affect the configuration
+func (r *DeviceConfigResource) readDeviceInSync(ctx context.Context, data *DeviceConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.OutOfSyncBehaviour.IsNull() {
+		data.DeviceInSync = types.BoolNull()
+		return diags
+	}
+	res, err := r.clients[data.Instance.ValueString()].PostData(data.getDeviceActionPath("check-sync"), `{"input":{}}`)
+	if err != nil {
+		diags.AddWarning("Failed to check device sync", fmt.Sprintf("The check-sync action of device '%s' failed: %s", data.Device.ValueString(), err))
+		data.DeviceInSync = types.BoolNull()
+	} else {
+		data.fromCheckSync(res.Res)
+	}
+	return diags
+}
@@ -21,13 +21,16 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccNsoDeviceConfig(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNsoDeviceConfigConfig_empty(),
@@ -53,6 +56,14 @@ func TestAccNsoDeviceConfig(t *testing.T) {
 					resource.TestCheckResourceAttr("nso_device_config.test", "attributes.tailf-ned-cisco-ios:hostname", "R2"),
 				),
 			},
+			{
+				Config: testAccNsoDeviceConfigConfig_outOfSyncBehaviour("R3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nso_device_config.test", "attributes.tailf-ned-cisco-ios:hostname", "R3"),
+					resource.TestCheckResourceAttr("nso_device_config.test", "out_of_sync_behaviour", "sync_from"),
+					resource.TestCheckResourceAttr("nso_device_config.test", "device_in_sync", "true"),
+				),
+			},
 			{
 				Config: testAccNsoDeviceConfigConfig_nested(),
 				Check: resource.ComposeTestCheckFunc(
@@ -64,6 +75,96 @@ func TestAccNsoDeviceConfig(t *testing.T) {
 	})
 }
 
+func TestDeviceConfigImportIdentity(t *testing.T) {
+	for _, tc := range []struct {
+		identity map[string]tftypes.Value
+		want     map[string]string
+	}{
+		{
+			identity: map[string]tftypes.Value{
+				"device": tftypes.NewValue(tftypes.String, "ce/1"),
+				"path":   tftypes.NewValue(tftypes.String, "tailf-ned-cisco-ios:access-list/access-list=1"),
+			},
+			want: map[string]string{
+				"id":     "tailf-ncs:devices/device=ce%2F1/config/tailf-ned-cisco-ios:access-list/access-list=1",
+				"device": "ce/1",
+				"path":   "tailf-ned-cisco-ios:access-list/access-list=1",
+			},
+		},
+		{
+			identity: map[string]tftypes.Value{"device": tftypes.NewValue(tftypes.String, "ce0")},
+			want:     map[string]string{"id": "tailf-ncs:devices/device=ce0/config", "device": "ce0", "path": "<null>"},
+		},
+	} {
+		state := testImportResourceState(t, "nso_device_config", tc.identity)
+		for name, want := range tc.want {
+			if got := testString(t, state[name]); got != want {
+				t.Errorf("%s = %q, want %q", name, got, want)
+			}
+		}
+	}
+}
+
+func TestSplitDeviceConfigPath(t *testing.T) {
+	for _, tc := range []struct {
+		id     string
+		device types.String
+		path   types.String
+		ok     bool
+	}{
+		{"tailf-ncs:devices/device=ce0/config", types.StringValue("ce0"), types.StringNull(), true},
+		{"tailf-ncs:devices/device=ce%2F1/config/tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1", types.StringValue("ce/1"), types.StringValue("tailf-ned-cisco-ios:interface/GigabitEthernet=0%2F1"), true},
+		{"tailf-ned-cisco-ios:access-list/access-list=1", types.StringNull(), types.StringNull(), false},
+		{"tailf-ncs:devices/device=ce0/config/", types.StringNull(), types.StringNull(), false},
+	} {
+		device, path, ok := splitDeviceConfigPath(tc.id)
+		if ok != tc.ok || !device.Equal(tc.device) || !path.Equal(tc.path) {
+			t.Errorf("splitDeviceConfigPath(%q) = %s, %s, %v, want %s, %s, %v", tc.id, device, path, ok, tc.device, tc.path, tc.ok)
+		}
+	}
+}
+
+func TestDeviceConfigReadDeviceInSync(t *testing.T) {
+	checks := 0
+	s := nsomock.NewServer(nsomock.WithAction("check-sync", func(string, map[string]interface{}) map[string]interface{} {
+		checks++
+		return map[string]interface{}{"result": "out-of-sync"}
+	}))
+	defer s.Close()
+	s.SetConfig("tailf-ncs:devices/device=ce0", `{"tailf-ncs:device":[{"name":"ce0","config":{"tailf-ned-cisco-ios:hostname":"R1"}}]}`)
+
+	state := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "tailf-ncs:devices/device=ce0/config"),
+		"device": tftypes.NewValue(tftypes.String, "ce0"),
+		"delete": tftypes.NewValue(tftypes.Bool, true),
+	}
+	result := testReadResource(t, s, "nso_device_config", state)
+	if checks != 0 || !result["device_in_sync"].IsNull() {
+		t.Errorf("without out_of_sync_behaviour: want no check-sync and device_in_sync null, got %d and %s", checks, result["device_in_sync"])
+	}
+
+	state["out_of_sync_behaviour"] = tftypes.NewValue(tftypes.String, "fail")
+	result = testReadResource(t, s, "nso_device_config", state)
+	if checks != 1 || !result["device_in_sync"].Equal(tftypes.NewValue(tftypes.Bool, false)) {
+		t.Errorf("with out_of_sync_behaviour: want check-sync and device_in_sync false, got %d and %s", checks, result["device_in_sync"])
+	}
+}
+
+func TestDeviceConfigUpgradeState(t *testing.T) {
+	// State of 0.2.x, which is already in the current format
+	state := testUpgradeResourceState(t, "nso_device_config", 0, `{"id":"tailf-ncs:devices/device=ce0/config","instance":null,"device":"ce0","path":null,"delete":true,"attributes":{"tailf-ned-cisco-ios:hostname":"R1"},"lists":null}`)
+	attributes := map[string]tftypes.Value{}
+	if err := state["attributes"].As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	if got := testString(t, attributes["tailf-ned-cisco-ios:hostname"]); got != "R1" {
+		t.Errorf("nso_device_config: hostname = %q, want %q", got, "R1")
+	}
+	if got := testString(t, state["device"]); got != "ce0" {
+		t.Errorf("nso_device_config: device = %q, want %q", got, "ce0")
+	}
+}
+
 func testAccNsoDeviceConfigConfig_empty() string {
 	return `
 	resource "nso_device_config" "test" {
@@ -83,6 +184,18 @@ func testAccNsoDeviceConfigConfig_hostname(hostname string) string {
 	`, hostname)
 }
 
+func testAccNsoDeviceConfigConfig_outOfSyncBehaviour(hostname string) string {
+	return fmt.Sprintf(`
+	resource "nso_device_config" "test" {
+		device = "ce0"
+		out_of_sync_behaviour = "sync_from"
+		attributes = {
+			"tailf-ned-cisco-ios:hostname" = "%s"
+		}
+	}
+	`, hostname)
+}
+
 func testAccNsoDeviceConfigConfig_nested() string {
 	return `
 	resource "nso_device_config" "nested" {
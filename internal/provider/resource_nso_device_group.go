@@ -22,18 +22,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/netascode/go-restconf"
+	"github.com/tidwall/gjson"
 )
 
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.ResourceWithIdentity = &DeviceGroupResource{}
+var _ resource.ResourceWithMoveState = &DeviceGroupResource{}
+
 func NewDeviceGroupResource() resource.Resource {
 	return &DeviceGroupResource{}
 }
@@ -70,13 +77,13 @@ func (r *DeviceGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"device_names": schema.ListAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("A list of device names.").String,
+			"device_names": schema.SetAttribute{
+				MarkdownDescription: helpers.NewAttributeDescription("A list of device names.").AddReferenceDescription("tailf-ncs:devices/device", "device").String,
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
-			"device_groups": schema.ListAttribute{
-				MarkdownDescription: helpers.NewAttributeDescription("A list of device groups.").String,
+			"device_groups": schema.SetAttribute{
+				MarkdownDescription: helpers.NewAttributeDescription("A list of device groups.").AddReferenceDescription("tailf-ncs:devices/device-group", "device_group").String,
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
@@ -84,6 +91,21 @@ func (r *DeviceGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 	}
 }
 
+func (r *DeviceGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"instance": identityschema.StringAttribute{
+				Description:       "An instance name from the provider configuration.",
+				OptionalForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "Device group name.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *DeviceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -92,6 +114,39 @@ func (r *DeviceGroupResource) Configure(_ context.Context, req resource.Configur
 	r.clients = req.ProviderData.(map[string]*restconf.Client)
 }
 
+// Check that entries referenced by leafrefs exist before anything is committed
+func (r *DeviceGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state DeviceGroup
+
+	// Read plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Instance.IsUnknown() {
+		return
+	}
+
+	// Read state
+	if !req.State.Raw.IsNull() {
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := r.clients[plan.Instance.ValueString()]
+	if req.State.Raw.IsNull() || !plan.DeviceNames.Equal(state.DeviceNames) {
+		resp.Diagnostics.Append(helpers.CheckReferences(ctx, client, path.Root("device_names"), plan.DeviceNames, "tailf-ncs:devices/device=%v")...)
+	}
+	if req.State.Raw.IsNull() || !plan.DeviceGroups.Equal(state.DeviceGroups) {
+		resp.Diagnostics.Append(helpers.CheckReferences(ctx, client, path.Root("device_groups"), plan.DeviceGroups, "tailf-ncs:devices/device-group=%v")...)
+	}
+}
+
 func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan DeviceGroup
 
@@ -115,12 +170,15 @@ func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 	emptyLeafsDelete := plan.getEmptyLeafsDelete(ctx)
 	tflog.Debug(ctx, fmt.Sprintf("List of empty leafs to delete: %+v", emptyLeafsDelete))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+
 	if YangPatch {
 		edits := []restconf.YangPatchEdit{restconf.NewYangPatchEdit("merge", plan.getPath(), restconf.Body{Str: body})}
 		for _, i := range emptyLeafsDelete {
 			edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 		}
-		_, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		res, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object, got error: %s", err))
 			return
@@ -128,14 +186,16 @@ func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 	} else {
 		res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 		if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-			_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+			res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 		}
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 			return
 		}
 		for _, i := range emptyLeafsDelete {
 			res, err := r.clients[plan.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -145,10 +205,19 @@ func (r *DeviceGroupResource) Create(ctx context.Context, req resource.CreateReq
 
 	plan.Id = types.StringValue(plan.getPath())
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
 }
 
 func (r *DeviceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -184,6 +253,9 @@ func (r *DeviceGroupResource) Read(ctx context.Context, req resource.ReadRequest
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, state.getIdentity())
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -218,6 +290,8 @@ func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	emptyLeafsDelete := plan.getEmptyLeafsDelete(ctx)
 	tflog.Debug(ctx, fmt.Sprintf("List of empty leafs to delete: %+v", emptyLeafsDelete))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+
 	if YangPatch {
 		edits := []restconf.YangPatchEdit{restconf.NewYangPatchEdit("merge", plan.getPath(), restconf.Body{Str: body})}
 		for _, i := range deletedListItems {
@@ -226,7 +300,8 @@ func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		for _, i := range emptyLeafsDelete {
 			edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 		}
-		_, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		res, err := r.clients[plan.Instance.ValueString()].YangPatchData("", "1", "", edits)
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update object, got error: %s", err))
 			return
@@ -234,14 +309,16 @@ func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	} else {
 		res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 		if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-			_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+			res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 		}
+		commitQueue.Add(res)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 			return
 		}
 		for _, i := range deletedListItems {
 			res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -249,6 +326,7 @@ func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 		for _, i := range emptyLeafsDelete {
 			res, err := r.clients[plan.Instance.ValueString()].DeleteData(i)
+			commitQueue.Add(res)
 			if err != nil && res.StatusCode != 404 {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -256,10 +334,19 @@ func (r *DeviceGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		}
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.Id.ValueString()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	diags = resp.Identity.Set(ctx, plan.getIdentity())
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.Id.ValueString()))
 }
 
 func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -280,8 +367,10 @@ func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Delete", state.Id.ValueString()))
 	deleteMode := "all"
 
+	commitQueue := helpers.NewCommitQueue(r.clients[state.Instance.ValueString()])
 	if deleteMode == "all" {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(state.Id.ValueString())
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 && res.StatusCode != 400 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
@@ -295,7 +384,8 @@ func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 			for _, i := range deletePaths {
 				edits = append(edits, restconf.NewYangPatchEdit("remove", i, restconf.Body{}))
 			}
-			_, err := r.clients[state.Instance.ValueString()].YangPatchData("", "1", "", edits)
+			res, err := r.clients[state.Instance.ValueString()].YangPatchData("", "1", "", edits)
+			commitQueue.Add(res)
 			if err != nil {
 				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 				return
@@ -303,6 +393,7 @@ func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		} else {
 			for _, i := range deletePaths {
 				res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+				commitQueue.Add(res)
 				if err != nil && res.StatusCode != 404 {
 					resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 					return
@@ -310,6 +401,10 @@ func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 			}
 		}
 	}
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Delete finished successfully", state.Id.ValueString()))
 
@@ -317,5 +412,70 @@ func (r *DeviceGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *DeviceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	var identity DeviceGroupIdentity
+
+	// Read identity
+	diags := req.Identity.Get(ctx, &identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := identity.toResource()
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Import", state.getPath()))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), state.getPath())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance"), state.Instance)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), state.Name)...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", state.getPath()))
+}
+
+func (r *DeviceGroupResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveRestconfState},
+	}
+}
+
+// An nso_restconf resource managing the same object can be migrated to this resource using a moved block
+func (r *DeviceGroupResource) moveRestconfState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "nso_restconf" || !strings.HasSuffix(strings.ToLower(req.SourceProviderAddress), "/ciscodevnet/nso") {
+		return
+	}
+
+	source, diags := getMovedRestconf(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if helpers.PathKeys("tailf-ncs:devices/device-group=%v", source.Path.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Move Resource State", fmt.Sprintf("The path '%s' of the nso_restconf resource is not a path of the nso_device_group resource.", source.Path.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Move", source.Path.ValueString()))
+
+	identity := DeviceGroup{Instance: source.Instance, Id: source.Path}.getIdentity()
+	state := identity.toResource()
+
+	var data DeviceGroupData
+	data.fromBody(ctx, gjson.Parse(source.toBody(ctx)))
+	state.DeviceNames = data.DeviceNames
+	state.DeviceGroups = data.DeviceGroups
+	state.Id = types.StringValue(state.getPath())
+
+	diags = resp.TargetState.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.TargetIdentity.Set(ctx, identity)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Move finished successfully", state.getPath()))
 }
@@ -22,19 +22,20 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccNsoDeviceGroup(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNsoDeviceGroupConfig_all(),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("nso_device_group.test", "name", "test-group1"),
-					resource.TestCheckResourceAttr("nso_device_group.test", "device_names.0", "ce0"),
+					resource.TestCheckTypeSetElemAttr("nso_device_group.test", "device_names.*", "ce0"),
 				),
 			},
 			{
@@ -46,6 +47,22 @@ func TestAccNsoDeviceGroup(t *testing.T) {
 	})
 }
 
+func TestDeviceGroupImportIdentity(t *testing.T) {
+	state := testImportResourceState(t, "nso_device_group", map[string]tftypes.Value{
+		"instance": tftypes.NewValue(tftypes.String, "nso2"),
+		"name":     tftypes.NewValue(tftypes.String, "test-group1"),
+	})
+	for name, want := range map[string]string{
+		"id":       "tailf-ncs:devices/device-group=test-group1",
+		"instance": "nso2",
+		"name":     "test-group1",
+	} {
+		if got := testString(t, state[name]); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
 func testAccNsoDeviceGroupConfig_minimum() string {
 	return `
 	resource "nso_device_group" "test" {
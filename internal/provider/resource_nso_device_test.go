@@ -22,13 +22,14 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccNsoDevice(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNsoDeviceConfig_all(),
@@ -36,6 +37,9 @@ func TestAccNsoDevice(t *testing.T) {
 					resource.TestCheckResourceAttr("nso_device.test", "name", "test-device01"),
 					resource.TestCheckResourceAttr("nso_device.test", "address", "10.1.1.1"),
 					resource.TestCheckResourceAttr("nso_device.test", "port", "22"),
+					resource.TestCheckResourceAttr("nso_device.test", "connect_timeout", "30"),
+					resource.TestCheckResourceAttr("nso_device.test", "read_timeout", "30"),
+					resource.TestCheckResourceAttr("nso_device.test", "write_timeout", "30"),
 					resource.TestCheckResourceAttr("nso_device.test", "authgroup", "default"),
 					resource.TestCheckResourceAttr("nso_device.test", "admin_state", "locked"),
 					resource.TestCheckResourceAttr("nso_device.test", "cli_ned_id", "cisco-ios-cli-3.8:cisco-ios-cli-3.8"),
@@ -50,6 +54,22 @@ func TestAccNsoDevice(t *testing.T) {
 	})
 }
 
+func TestDeviceImportIdentity(t *testing.T) {
+	state := testImportResourceState(t, "nso_device", map[string]tftypes.Value{
+		"instance": tftypes.NewValue(tftypes.String, "nso2"),
+		"name":     tftypes.NewValue(tftypes.String, "test-device01"),
+	})
+	for name, want := range map[string]string{
+		"id":       "tailf-ncs:devices/device=test-device01",
+		"instance": "nso2",
+		"name":     "test-device01",
+	} {
+		if got := testString(t, state[name]); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
 func testAccNsoDeviceConfig_minimum() string {
 	return `
 	resource "nso_device" "test" {
@@ -64,6 +84,9 @@ func testAccNsoDeviceConfig_all() string {
 		name = "test-device01"
 		address = "10.1.1.1"
 		port = 22
+		connect_timeout = 30
+		read_timeout = 30
+		write_timeout = 30
 		authgroup = "default"
 		admin_state = "locked"
 		cli_ned_id = "cisco-ios-cli-3.8:cisco-ios-cli-3.8"
@@ -21,13 +21,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/netascode/go-restconf"
 )
@@ -35,6 +40,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RestconfResource{}
 var _ resource.ResourceWithImportState = &RestconfResource{}
+var _ resource.ResourceWithUpgradeState = &RestconfResource{}
 
 func NewRestconfResource() resource.Resource {
 	return &RestconfResource{}
@@ -52,6 +58,7 @@ func (r *RestconfResource) Schema(ctx context.Context, req resource.SchemaReques
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Manages NSO configuration via RESTCONF calls. This resource manages part of a YANG model. It is able to read the state and therefore reconcile configuration drift.",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"instance": schema.StringAttribute{
@@ -139,11 +146,13 @@ func (r *RestconfResource) Create(ctx context.Context, req resource.CreateReques
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Create", plan.getPath()))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
 	body := plan.toBody(ctx)
 	res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 	if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-		_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+		res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 	}
+	commitQueue.Add(res)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 		return
@@ -155,10 +164,16 @@ func (r *RestconfResource) Create(ctx context.Context, req resource.CreateReques
 		plan.Attributes = types.MapNull(types.StringType)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
 }
 
 func (r *RestconfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -221,11 +236,13 @@ func (r *RestconfResource) Update(ctx context.Context, req resource.UpdateReques
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Update", plan.getPath()))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
 	body := plan.toBody(ctx)
 	res, err := r.clients[plan.Instance.ValueString()].PatchData(plan.getPathShort(), body)
 	if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
-		_, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+		res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
 	}
+	commitQueue.Add(res)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure object (PATCH), got error: %s", err))
 		return
@@ -236,6 +253,7 @@ func (r *RestconfResource) Update(ctx context.Context, req resource.UpdateReques
 
 	for _, i := range deletedListItems {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
@@ -246,10 +264,16 @@ func (r *RestconfResource) Update(ctx context.Context, req resource.UpdateReques
 		plan.Attributes = types.MapNull(types.StringType)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.getPath()))
-
+	// The configuration is committed, save state before waiting for the commit queue to not lose track of it
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.getPath()))
 }
 
 func (r *RestconfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -269,14 +293,21 @@ func (r *RestconfResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Delete", state.getPath()))
 
+	commitQueue := helpers.NewCommitQueue(r.clients[state.Instance.ValueString()])
 	if state.Delete.ValueBool() {
 		res, err := r.clients[state.Instance.ValueString()].DeleteData(state.getPath())
+		commitQueue.Add(res)
 		if err != nil && res.StatusCode != 404 {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
 			return
 		}
 	}
 
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("%s: Delete finished successfully", state.getPath()))
 
 	resp.State.RemoveResource(ctx)
@@ -292,3 +323,56 @@ func (r *RestconfResource) ImportState(ctx context.Context, req resource.ImportS
 
 	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", req.ID))
 }
+
+// Schema version 0 covers the states of 0.1.x, with list items holding an attributes map, as well as the states of
+// 0.2.x
+func (r *RestconfResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeListItemsState},
+	}
+}
+
+// upgradeListItemsState upgrades a state of schema version 0 of the nso_restconf or nso_device_config resource
+func upgradeListItemsState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	value, err := upgradeListItemsRawState(req.RawState, resp.State.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Failed to upgrade state of schema version 0, got error: %s", err))
+		return
+	}
+	resp.State.Raw = value
+}
+
+// upgradeListItemsRawState decodes a raw state of any schema version with the current schema, attributes which were
+// removed since are ignored
+func upgradeListItemsRawState(rawState *tfprotov6.RawState, typ tftypes.Type) (tftypes.Value, error) {
+	if rawState == nil || rawState.JSON == nil {
+		return tftypes.Value{}, fmt.Errorf("missing JSON state")
+	}
+	state, err := helpers.UpgradeListItems(rawState.JSON)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	upgraded := tfprotov6.RawState{JSON: state}
+	return upgraded.UnmarshalWithOpts(typ, tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+	})
+}
+
+// getMovedRestconf reads the state of an nso_restconf resource, which is moved to another resource type by a moved
+// block. The source state is not upgraded by Terraform before, so older schema versions are upgraded here.
+func getMovedRestconf(ctx context.Context, req resource.MoveStateRequest) (Restconf, diag.Diagnostics) {
+	var source Restconf
+	var diags diag.Diagnostics
+
+	var schemaResp resource.SchemaResponse
+	NewRestconfResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	value, err := upgradeListItemsRawState(req.SourceRawState, schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		diags.AddError("Unable to Move Resource State", fmt.Sprintf("Failed to read state of nso_restconf resource, got error: %s", err))
+		return source, diags
+	}
+	state := tfsdk.State{Raw: value, Schema: schemaResp.Schema}
+	diags.Append(state.Get(ctx, &source)...)
+	return source, diags
+}
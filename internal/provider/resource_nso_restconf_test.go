@@ -18,16 +18,22 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccNsoRestconf(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNsoRestconfConfig_empty(),
@@ -70,6 +76,94 @@ func TestAccNsoRestconf(t *testing.T) {
 	})
 }
 
+func TestRestconfUpgradeState(t *testing.T) {
+	// State of 0.1.x with an attributes map of each list item
+	state := testUpgradeResourceState(t, "nso_restconf", 0, `{"id":"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:access-list","instance":null,"path":"tailf-ncs:devices/device=ce0/config/tailf-ned-cisco-ios:access-list","delete":true,"attributes":null,"lists":[{"name":"access-list","key":"id","items":[{"attributes":{"id":"1"}}]}]}`)
+	var lists []tftypes.Value
+	if err := state["lists"].As(&lists); err != nil || len(lists) != 1 {
+		t.Fatalf("nso_restconf: lists = %v, want 1 list", state["lists"])
+	}
+	list := map[string]tftypes.Value{}
+	if err := lists[0].As(&list); err != nil {
+		t.Fatal(err)
+	}
+	var items []tftypes.Value
+	if err := list["items"].As(&items); err != nil || len(items) != 1 {
+		t.Fatalf("nso_restconf: items = %v, want 1 item", list["items"])
+	}
+	item := map[string]tftypes.Value{}
+	if err := items[0].As(&item); err != nil {
+		t.Fatal(err)
+	}
+	if got := testString(t, item["id"]); got != "1" {
+		t.Errorf("nso_restconf: item id = %q, want %q", got, "1")
+	}
+}
+
+// testMoveResourceState moves a raw JSON state of an nso_restconf resource to the given resource type like a moved
+// block does and returns the attributes of the target state and the error diagnostics
+func testMoveResourceState(t *testing.T, typeName string, version int64, state string) (map[string]tftypes.Value, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/CiscoDevNet/nso",
+		SourceTypeName:        "nso_restconf",
+		SourceSchemaVersion:   version,
+		SourceState:           &tfprotov6.RawState{JSON: []byte(state)},
+		TargetTypeName:        typeName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := testProtoErrors(resp.Diagnostics); len(errs) > 0 {
+		return nil, errs
+	}
+	return testDynamicValueAttributes(t, resp.TargetState, schemas.ResourceSchemas[typeName].ValueType()), nil
+}
+
+func TestRestconfMoveState(t *testing.T) {
+	state, errs := testMoveResourceState(t, "nso_device", 1, `{"id":"tailf-ncs:devices/device=ce%2F1","instance":"nso2","path":"tailf-ncs:devices/device=ce%2F1","delete":true,"attributes":{"address":"10.1.1.1","port":"22","authgroup":"default"},"lists":null}`)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	for name, want := range map[string]string{"id": "tailf-ncs:devices/device=ce%2F1", "instance": "nso2", "name": "ce/1", "address": "10.1.1.1", "authgroup": "default"} {
+		if got := testString(t, state[name]); got != want {
+			t.Errorf("nso_device: %s = %q, want %q", name, got, want)
+		}
+	}
+	var port big.Float
+	if err := state["port"].As(&port); err != nil || port.String() != "22" {
+		t.Errorf("nso_device: port = %v, want 22", state["port"])
+	}
+
+	// Leaf-lists are managed by the lists attribute of the nso_restconf resource, the state of 0.1.x is upgraded
+	state, errs = testMoveResourceState(t, "nso_device_group", 0, `{"id":"tailf-ncs:devices/device-group=g1","path":"tailf-ncs:devices/device-group=g1","delete":true,"attributes":{"name":"g1"},"lists":[{"name":"device-name","key":null,"items":null,"values":["ce0","ce1"]}]}`)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	var deviceNames []tftypes.Value
+	if err := state["device_names"].As(&deviceNames); err != nil || len(deviceNames) != 2 {
+		t.Errorf("nso_device_group: device_names = %v, want 2 devices", state["device_names"])
+	}
+	if got := testString(t, state["name"]); got != "g1" {
+		t.Errorf("nso_device_group: name = %q, want %q", got, "g1")
+	}
+
+	_, errs = testMoveResourceState(t, "nso_device", 1, `{"id":"tailf-ncs:devices/device-group=g1","path":"tailf-ncs:devices/device-group=g1","delete":true,"attributes":null,"lists":null}`)
+	if len(errs) != 1 || !strings.Contains(errs[0], "is not a path of the nso_device resource") {
+		t.Errorf("nso_device: want path error, got %v", errs)
+	}
+}
+
 func testAccNsoRestconfConfig_empty() string {
 	return `
 	resource "nso_restconf" "test" {
@@ -0,0 +1,117 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNsoRollback(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccLivePreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsoRollbackConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nso_rollback.test", "id"),
+				),
+			},
+			{
+				Config:      testAccNsoRollbackConfig_notFound,
+				ExpectError: regexp.MustCompile("Rollback file not found"),
+			},
+		},
+	})
+}
+
+const testAccNsoRollbackConfig = `
+resource "nso_restconf" "test" {
+	path = "tailf-ncs:ssh"
+	attributes = {
+		host-key-verification = "reject-unknown"
+	}
+}
+
+resource "nso_rollback" "test" {
+	rollback_id = 0
+	paths       = ["/ncs:ssh"]
+	depends_on  = [nso_restconf.test]
+}
+`
+
+const testAccNsoRollbackConfig_notFound = `
+resource "nso_rollback" "test" {
+	label = "terraform-provider-nso-missing-label"
+}
+`
+
+// testCreateResource creates a resource against the mock server and returns the new state and the error diagnostics
+func testCreateResource(t *testing.T, s *nsomock.Server, typeName string, plan map[string]tftypes.Value) (map[string]tftypes.Value, []string) {
+	t.Helper()
+	ctx := context.Background()
+	server, schemas := testConfigureProvider(t, s)
+
+	typ := schemas.ResourceSchemas[typeName].ValueType()
+	null, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   &null,
+		PlannedState: testDynamicValue(t, typ, plan),
+		Config:       testDynamicValue(t, typ, plan),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NewState == nil {
+		return nil, testProtoErrors(resp.Diagnostics)
+	}
+	return testDynamicValueAttributes(t, resp.NewState, typ), testProtoErrors(resp.Diagnostics)
+}
+
+func TestRollbackCreateCommitQueue(t *testing.T) {
+	s := nsomock.NewServer(nsomock.WithAction("apply-rollback-file", func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"commit-queue": map[string]interface{}{"id": 8, "status": "async"}}
+	}))
+	defer s.Close()
+	s.SetOperational("tailf-rollback:rollback-files", `{"tailf-rollback:rollback-files":{"file":[{"id":0,"fixed-number":10042}]}}`)
+	s.SetOperational("tailf-ncs:devices/commit-queue/queue-item=8", `{"tailf-ncs:queue-item":[{"id":8,"status":"failed","devices":["ce0"]}]}`)
+
+	state, errs := testCreateResource(t, s, "nso_rollback", map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"rollback_id": tftypes.NewValue(tftypes.Number, 0),
+	})
+	if len(errs) != 1 || !strings.Contains(errs[0], "Commit queue item 8 is failed") {
+		t.Errorf("want failed commit queue item of apply-rollback-file, got %v", errs)
+	}
+	// The rollback is committed even if the commit queue item fails
+	if got := testString(t, state["id"]); got != "10042" {
+		t.Errorf("nso_rollback: id = %q, want %q", got, "10042")
+	}
+}
@@ -0,0 +1,538 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/provider/helpers"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/netascode/go-restconf"
+)
+
+// Interval between two reads of the service plan
+var servicePlanPollInterval = 5 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ServiceResource{}
+var _ resource.ResourceWithImportState = &ServiceResource{}
+var _ resource.ResourceWithModifyPlan = &ServiceResource{}
+
+func NewServiceResource() resource.Resource {
+	return &ServiceResource{}
+}
+
+type ServiceResource struct {
+	clients map[string]*restconf.Client
+}
+
+func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service"
+}
+
+func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages an NSO service instance. The service configuration is defined like in the `nso_restconf` resource. For nano services the resource can wait until the service plan is ready. The result of the `check-sync` and `get-modifications` service actions is exposed to detect drift of the device configuration, which can be resolved by a `re-deploy` of the service. As the actions contact the devices, they only run on refresh if `check_sync` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance": schema.StringAttribute{
+				MarkdownDescription: "An instance name from the provider configuration.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The RESTCONF path.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The RESTCONF path of the service instance, e.g. `my-service:my-service=instance1`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"delete": schema.BoolAttribute{
+				MarkdownDescription: "Delete service instance during destroy operation. Default value is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"undeploy_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Un-deploy the service during destroy operation instead of deleting it. The device configuration is removed, but the service configuration is kept. Default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Map of key-value pairs which represents the YANG leafs and its values.",
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"lists": schema.ListNestedAttribute{
+				MarkdownDescription: "YANG lists.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "YANG list name.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							MarkdownDescription: "YANG list key attribute. In case of multiple keys, those should be separated by a comma (`,`).",
+							Optional:            true,
+						},
+						"items": schema.ListAttribute{
+							MarkdownDescription: "List of maps of key-value pairs which represents the YANG leafs and its values.",
+							Optional:            true,
+							ElementType:         types.MapType{ElemType: types.StringType},
+						},
+						"values": schema.ListAttribute{
+							MarkdownDescription: "YANG leaf-list values.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"wait_for_plan": schema.SingleNestedAttribute{
+				MarkdownDescription: "Wait for all states of all components of the nano-service plan to be reached after create and update. A failed plan results in an error including the `error-info` of the plan.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int64Attribute{
+						MarkdownDescription: "Maximum time in seconds to wait for the plan. Default value is `600`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(600),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"redeploy_trigger": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will re-deploy the service.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"redeploy_reconcile": schema.BoolAttribute{
+				MarkdownDescription: "Reconcile the service when re-deploying it, configuration already present on the devices is taken over by the service. Default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"modifications_format": schema.StringAttribute{
+				MarkdownDescription: helpers.NewAttributeDescription("Format of `device_modifications`. Default value is `cli`.").AddStringEnumDescription("cli", "native").String,
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("cli"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("cli", "native"),
+				},
+			},
+			"check_sync": schema.BoolAttribute{
+				MarkdownDescription: "Run the `check-sync` and `get-modifications` actions on every refresh to detect drift of the device configuration. The actions contact the devices, by default they only run after create, update and import. Default value is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"in_sync": schema.BoolAttribute{
+				MarkdownDescription: "Result of the `check-sync` action, `false` if the device configuration deviates from the configuration of the service.",
+				Computed:            true,
+			},
+			"device_modifications": schema.StringAttribute{
+				MarkdownDescription: "Result of the `get-modifications` action, the device configuration created by the service.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ServiceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(map[string]*restconf.Client)
+}
+
+func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan Service
+
+	// Read plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, ok := r.clients[plan.Instance.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", plan.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Create", plan.getPath()))
+
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+	data := plan.toRestconf()
+	body := data.toBody(ctx)
+	res, err := r.clients[plan.Instance.ValueString()].PatchData(data.getPathShort(), body)
+	if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
+		res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+	}
+	commitQueue.Add(res)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure service (PATCH), got error: %s", err))
+		return
+	}
+
+	plan.Id = plan.Path
+
+	if plan.Attributes.IsUnknown() {
+		plan.Attributes = types.MapNull(types.StringType)
+	}
+	plan.InSync = types.BoolNull()
+	plan.DeviceModifications = types.StringNull()
+
+	// The service is committed, save state before waiting for the plan to not lose track of it
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitForPlan != nil {
+		resp.Diagnostics.Append(r.waitForPlan(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.readServiceStatus(ctx, &plan)...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Create finished successfully", plan.getPath()))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state Service
+
+	// Read state
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, ok := r.clients[state.Instance.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", state.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Read", state.getPath()))
+
+	res, err := r.clients[state.Instance.ValueString()].GetData(state.getPath(), restconf.Query("content", "config"))
+	if res.StatusCode == 404 {
+		state.Attributes = types.MapNull(types.StringType)
+		state.Lists = make([]RestconfList, 0)
+	} else {
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to read service, got error: %s", err))
+			return
+		}
+
+		data := state.toRestconf()
+		data.fromBody(ctx, res.Res)
+		state.fromRestconf(data)
+
+		// The status has not been read yet after import
+		if state.CheckSync.ValueBool() || (state.InSync.IsNull() && state.DeviceModifications.IsNull()) {
+			resp.Diagnostics.Append(r.readServiceStatus(ctx, &state)...)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Read finished successfully", state.getPath()))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state Service
+
+	// Read plan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read state
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, ok := r.clients[plan.Instance.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", plan.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Update", plan.getPath()))
+
+	commitQueue := helpers.NewCommitQueue(r.clients[plan.Instance.ValueString()])
+	data := plan.toRestconf()
+	body := data.toBody(ctx)
+	res, err := r.clients[plan.Instance.ValueString()].PatchData(data.getPathShort(), body)
+	if len(res.Errors.Error) > 0 && res.Errors.Error[0].ErrorMessage == "patch to a nonexistent resource" {
+		res, err = r.clients[plan.Instance.ValueString()].PutData(plan.getPath(), body)
+	}
+	commitQueue.Add(res)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to configure service (PATCH), got error: %s", err))
+		return
+	}
+
+	deletedListItems := data.getDeletedListItems(ctx, state.toRestconf())
+	tflog.Debug(ctx, fmt.Sprintf("List items to delete: %+v", deletedListItems))
+
+	for _, i := range deletedListItems {
+		res, err := r.clients[state.Instance.ValueString()].DeleteData(i)
+		commitQueue.Add(res)
+		if err != nil && res.StatusCode != 404 {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete object, got error: %s", err))
+			return
+		}
+	}
+
+	if !plan.RedeployTrigger.IsNull() && !plan.RedeployTrigger.Equal(state.RedeployTrigger) {
+		tflog.Debug(ctx, fmt.Sprintf("%s: Re-deploying service", plan.getPath()))
+		res, err := r.clients[plan.Instance.ValueString()].PostData(plan.getActionPath("re-deploy"), plan.getRedeployBody())
+		commitQueue.Add(res)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to re-deploy service, got error: %s", err))
+			return
+		}
+	}
+
+	if plan.Attributes.IsUnknown() {
+		plan.Attributes = types.MapNull(types.StringType)
+	}
+	plan.InSync = types.BoolNull()
+	plan.DeviceModifications = types.StringNull()
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitForPlan != nil {
+		resp.Diagnostics.Append(r.waitForPlan(ctx, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.readServiceStatus(ctx, &plan)...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Update finished successfully", plan.getPath()))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state Service
+
+	// Read state
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, ok := r.clients[state.Instance.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("instance"), "Invalid instance", fmt.Sprintf("Instance '%s' does not exist in provider configuration.", state.Instance.ValueString()))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Delete", state.getPath()))
+
+	commitQueue := helpers.NewCommitQueue(r.clients[state.Instance.ValueString()])
+	if state.UndeployOnDestroy.ValueBool() {
+		res, err := r.clients[state.Instance.ValueString()].PostData(state.getActionPath("un-deploy"), `{"input":{}}`)
+		commitQueue.Add(res)
+		if err != nil && res.StatusCode != 404 {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to un-deploy service, got error: %s", err))
+			return
+		}
+	} else if state.Delete.ValueBool() {
+		res, err := r.clients[state.Instance.ValueString()].DeleteData(state.getPath())
+		commitQueue.Add(res)
+		if err != nil && res.StatusCode != 404 {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete service, got error: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(commitQueue.Wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Delete finished successfully", state.getPath()))
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Beginning Import", req.ID))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+
+	tflog.Debug(ctx, fmt.Sprintf("%s: Import finished successfully", req.ID))
+}
+
+// Warn about services which are out of sync, the device configuration can be restored with a re-deploy
+func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state Service
+
+	// Read state
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.InSync.IsNull() && !state.InSync.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("in_sync"), "Service out of sync", fmt.Sprintf("The device configuration of service '%s' deviates from the configuration of the service. Change 'redeploy_trigger' to re-deploy the service.", state.getPath()))
+	}
+}
+
+// Read the sync status and modifications of a service, failures are reported as warnings as they do not affect
+// the service configuration
+func (r *ServiceResource) readServiceStatus(ctx context.Context, data *Service) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := r.clients[data.Instance.ValueString()]
+
+	res, err := client.PostData(data.getActionPath("check-sync"), `{"input":{}}`)
+	if err != nil {
+		diags.AddWarning("Failed to check service sync", fmt.Sprintf("The check-sync action of service '%s' failed: %s", data.getPath(), err))
+		data.InSync = types.BoolNull()
+	} else {
+		data.fromCheckSync(res.Res)
+	}
+
+	res, err = client.PostData(data.getActionPath("get-modifications"), data.getModificationsBody())
+	if err != nil {
+		diags.AddWarning("Failed to get service modifications", fmt.Sprintf("The get-modifications action of service '%s' failed: %s", data.getPath(), err))
+		data.DeviceModifications = types.StringNull()
+	} else {
+		data.fromModifications(res.Res)
+	}
+	return diags
+}
+
+// Poll the nano-service plan until all states are reached, a state failed or the timeout expired
+func (r *ServiceResource) waitForPlan(ctx context.Context, data Service) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := r.clients[data.Instance.ValueString()]
+	timeout := time.Duration(data.WaitForPlan.Timeout.ValueInt64()) * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for {
+		tflog.Debug(ctx, fmt.Sprintf("%s: Reading service plan", data.getPlanPath()))
+		res, err := client.GetData(data.getPlanPath(), restconf.Query("content", "nonconfig"))
+		if res.StatusCode == 404 {
+			diags.AddAttributeError(path.Root("wait_for_plan"), "Service plan not found", fmt.Sprintf("The service '%s' has no plan, waiting for the plan is only supported for nano services.", data.getPath()))
+			return diags
+		}
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Failed to read service plan, got error: %s", err))
+			return diags
+		}
+
+		plan := parseServicePlan(res.Res)
+		if plan.isFailed() {
+			detail := fmt.Sprintf("The plan of service '%s' failed.", data.getPath())
+			if len(plan.Failures) > 0 {
+				detail += "\n\nFailed states:\n  " + strings.Join(plan.Failures, "\n  ")
+			}
+			if plan.ErrorInfo != "" {
+				detail += "\n\nError info:\n" + plan.ErrorInfo
+			}
+			diags.AddError("Service plan failed", detail)
+			return diags
+		}
+		if plan.isReady() {
+			tflog.Debug(ctx, fmt.Sprintf("%s: Service plan ready", data.getPlanPath()))
+			return diags
+		}
+		if time.Now().Add(servicePlanPollInterval).After(deadline) {
+			diags.AddError("Timeout waiting for service plan", fmt.Sprintf("The plan of service '%s' was not ready after %v.\n\nPending states:\n  %s", data.getPath(), timeout, strings.Join(plan.Pending, "\n  ")))
+			return diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Service plan wait cancelled", fmt.Sprintf("Waiting for the plan of service '%s' was cancelled: %s", data.getPath(), ctx.Err()))
+			return diags
+		case <-time.After(servicePlanPollInterval):
+		}
+	}
+}
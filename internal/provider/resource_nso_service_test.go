@@ -0,0 +1,184 @@
+// Copyright © 2023 Cisco Systems, Inc. and its affiliates.
+// All rights reserved.
+//
+// Licensed under the Mozilla Public License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://mozilla.org/MPL/2.0/
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/CiscoDevNet/terraform-provider-nso/internal/nsomock"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNsoService(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNsoServiceConfig("Customer 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nso_service.test", "id", "tailf-ncs:customers/customer=SVC1"),
+					resource.TestCheckResourceAttr("nso_service.test", "attributes.rank", "1"),
+				),
+			},
+			{
+				ResourceName:  "nso_service.test",
+				ImportState:   true,
+				ImportStateId: "tailf-ncs:customers/customer=SVC1",
+			},
+			{
+				Config: testAccNsoServiceConfig("Customer 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nso_service.test", "attributes.description", "Customer 2"),
+				),
+			},
+			{
+				Config:      testAccNsoServiceConfig_waitForPlan(),
+				ExpectError: regexp.MustCompile("Service plan not found"),
+			},
+		},
+	})
+}
+
+func testAccNsoServiceConfig(description string) string {
+	return fmt.Sprintf(`
+	resource "nso_service" "test" {
+		path = "tailf-ncs:customers/customer=SVC1"
+		attributes = {
+			id = "SVC1"
+			rank = 1
+			description = "%s"
+		}
+	}
+	`, description)
+}
+
+func testAccNsoServiceConfig_waitForPlan() string {
+	return `
+	resource "nso_service" "test" {
+		path = "tailf-ncs:customers/customer=SVC1"
+		attributes = {
+			id = "SVC1"
+			rank = 1
+			description = "Customer 2"
+		}
+		wait_for_plan = {
+			timeout = 10
+		}
+	}
+	`
+}
+
+// testReadResource refreshes a resource against the mock server and returns the attributes of the new state
+func testReadResource(t *testing.T, s *nsomock.Server, typeName string, state map[string]tftypes.Value) map[string]tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+	server, schemas := testConfigureProvider(t, s)
+
+	typ := schemas.ResourceSchemas[typeName].ValueType()
+	resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: testDynamicValue(t, typ, state),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := testProtoErrors(resp.Diagnostics); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return testDynamicValueAttributes(t, resp.NewState, typ)
+}
+
+func TestServiceReadStatus(t *testing.T) {
+	checks := 0
+	s := nsomock.NewServer(nsomock.WithAction("check-sync", func(string, map[string]interface{}) map[string]interface{} {
+		checks++
+		return map[string]interface{}{"in-sync": false}
+	}))
+	defer s.Close()
+	s.SetConfig("tailf-ncs:customers/customer=SVC1", `{"tailf-ncs:customer":[{"id":"SVC1","rank":1}]}`)
+
+	state := map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "tailf-ncs:customers/customer=SVC1"),
+		"path": tftypes.NewValue(tftypes.String, "tailf-ncs:customers/customer=SVC1"),
+	}
+	// After import the status has not been read yet
+	result := testReadResource(t, s, "nso_service", state)
+	if checks != 1 || !result["in_sync"].Equal(tftypes.NewValue(tftypes.Bool, false)) {
+		t.Errorf("after import: want 1 check-sync and in_sync false, got %d and %s", checks, result["in_sync"])
+	}
+
+	state["in_sync"] = tftypes.NewValue(tftypes.Bool, true)
+	state["check_sync"] = tftypes.NewValue(tftypes.Bool, false)
+	result = testReadResource(t, s, "nso_service", state)
+	if checks != 1 || !result["in_sync"].Equal(tftypes.NewValue(tftypes.Bool, true)) {
+		t.Errorf("refresh: want no check-sync and in_sync kept, got %d and %s", checks, result["in_sync"])
+	}
+
+	state["check_sync"] = tftypes.NewValue(tftypes.Bool, true)
+	result = testReadResource(t, s, "nso_service", state)
+	if checks != 2 || !result["in_sync"].Equal(tftypes.NewValue(tftypes.Bool, false)) {
+		t.Errorf("refresh with check_sync: want check-sync and in_sync false, got %d and %s", checks, result["in_sync"])
+	}
+}
+
+// testDeleteResource destroys a resource against the mock server and returns the error diagnostics
+func testDeleteResource(t *testing.T, s *nsomock.Server, typeName string, state map[string]tftypes.Value) []string {
+	t.Helper()
+	ctx := context.Background()
+	server, schemas := testConfigureProvider(t, s)
+
+	typ := schemas.ResourceSchemas[typeName].ValueType()
+	null, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   testDynamicValue(t, typ, state),
+		PlannedState: &null,
+		Config:       &null,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testProtoErrors(resp.Diagnostics)
+}
+
+func TestServiceUndeployCommitQueue(t *testing.T) {
+	s := nsomock.NewServer(nsomock.WithAction("un-deploy", func(string, map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"commit-queue": map[string]interface{}{"id": 7, "status": "async"}}
+	}))
+	defer s.Close()
+	s.SetOperational("tailf-ncs:devices/commit-queue/queue-item=7", `{"tailf-ncs:queue-item":[{"id":7,"status":"failed","devices":["ce0"]}]}`)
+
+	errs := testDeleteResource(t, s, "nso_service", map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "tailf-ncs:customers/customer=SVC1"),
+		"path":                tftypes.NewValue(tftypes.String, "tailf-ncs:customers/customer=SVC1"),
+		"undeploy_on_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if len(errs) != 1 || !strings.Contains(errs[0], "Commit queue item 7 is failed") {
+		t.Errorf("want failed commit queue item of un-deploy, got %v", errs)
+	}
+}
@@ -27,7 +27,7 @@ import (
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
 
-// Donwload YANG models.
+// Fetch and verify YANG models pinned in gen/models.yaml, set NSO_GEN_OFFLINE to only use cached models.
 //go:generate go run gen/load_models.go
 
 // Run the resource and datasource generation tool.